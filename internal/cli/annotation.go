@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const githubActionsEnv = "GITHUB_ACTIONS"
+
+// annotationReporter writes workflow commands to annotate the jobs which blocked the validation,
+// so that they are listed in the checks panel of the pull request.
+// NOTE: https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-error-message
+type annotationReporter struct {
+	w io.Writer
+}
+
+func (ar *annotationReporter) Report(_ context.Context, r *report) error {
+	if !r.Final || r.Result != resultFailure {
+		return nil
+	}
+
+	_, err := io.WriteString(ar.w, renderAnnotations(r))
+	return err
+}
+
+func renderAnnotations(r *report) string {
+	var sb strings.Builder
+	for _, job := range r.jobs(validators.JobStateFailure, validators.JobStatePending, validators.JobStateMissing) {
+		command, title, message := "warning", "", ""
+		switch job.State {
+		case validators.JobStateFailure:
+			command = "error"
+			title = "Job failed"
+			switch job.Conclusion {
+			case "cancelled":
+				title = "Job cancelled"
+				message = fmt.Sprintf("%s was cancelled", job.Name)
+			case "", "failure":
+				message = fmt.Sprintf("%s failed", job.Name)
+			default:
+				// Conclusions such as timed_out or action_required tell more than "failed" does.
+				message = fmt.Sprintf("%s failed with %s", job.Name, job.Conclusion)
+			}
+		case validators.JobStatePending:
+			title = "Job still pending"
+			message = fmt.Sprintf("%s was still pending when the validation ended", job.Name)
+		case validators.JobStateMissing:
+			title = "Required job missing"
+			message = fmt.Sprintf("%s is required, but was never reported", job.Name)
+		}
+		if len(job.URL) != 0 {
+			message += fmt.Sprintf("\nDetails: %s", job.URL)
+		}
+		fmt.Fprintf(&sb, "::%s title=%s::%s\n", command, escapeCommandProperty("Merge Gatekeeper: "+title), escapeCommandData(message))
+	}
+	return sb.String()
+}
+
+// NOTE: https://github.com/actions/toolkit/blob/main/packages/core/src/command.ts
+func escapeCommandData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeCommandProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func Test_renderAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		jobs []*validators.Job
+		want string
+	}{
+		"returns annotations for blocking jobs": {
+			jobs: []*validators.Job{
+				{Name: "build", State: validators.JobStateSuccess},
+				{Name: "test (ubuntu-latest, 1.21)", State: validators.JobStateFailure, Conclusion: "failure", URL: "https://github.com/upsidr/merge-gatekeeper/runs/1"},
+				{Name: "e2e", State: validators.JobStateFailure, Conclusion: "cancelled"},
+				{Name: "integration", State: validators.JobStateFailure, Conclusion: "timed_out"},
+				{Name: "deploy", State: validators.JobStatePending, URL: "https://example.com/deploy"},
+				{Name: "release", State: validators.JobStateMissing},
+				{Name: "lint", State: validators.JobStateIgnored},
+			},
+			want: "::error title=Merge Gatekeeper%3A Job failed::test (ubuntu-latest, 1.21) failed%0ADetails: https://github.com/upsidr/merge-gatekeeper/runs/1\n" +
+				"::error title=Merge Gatekeeper%3A Job cancelled::e2e was cancelled\n" +
+				"::error title=Merge Gatekeeper%3A Job failed::integration failed with timed_out\n" +
+				"::warning title=Merge Gatekeeper%3A Job still pending::deploy was still pending when the validation ended%0ADetails: https://example.com/deploy\n" +
+				"::warning title=Merge Gatekeeper%3A Required job missing::release is required, but was never reported\n",
+		},
+		"escapes job names": {
+			jobs: []*validators.Job{
+				{Name: "100%\nbroken", State: validators.JobStateFailure},
+			},
+			want: "::error title=Merge Gatekeeper%3A Job failed::100%25%0Abroken failed\n",
+		},
+		"returns nothing when no job blocked the validation": {
+			jobs: []*validators.Job{
+				{Name: "build", State: validators.JobStateSuccess},
+			},
+			want: "",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &report{
+				Result:     resultFailure,
+				Final:      true,
+				Validators: []*validatorReport{{Name: "merge-gatekeeper", Jobs: tt.jobs}},
+			}
+			if got := renderAnnotations(r); got != tt.want {
+				t.Errorf("renderAnnotations() didn't match\n  got:\n%s\n\n  want:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_annotationReporter_Report(t *testing.T) {
+	jobs := []*validators.Job{{Name: "test", State: validators.JobStateFailure}}
+	tests := map[string]struct {
+		r        *report
+		wantEmit bool
+	}{
+		"writes annotations when the validation failed": {
+			r:        &report{Result: resultFailure, Final: true, Validators: []*validatorReport{{Jobs: jobs}}},
+			wantEmit: true,
+		},
+		"writes nothing for intermediate reports": {
+			r:        &report{Result: resultPending, Validators: []*validatorReport{{Jobs: jobs}}},
+			wantEmit: false,
+		},
+		"writes nothing when the validation succeeded": {
+			r:        &report{Result: resultSuccess, Final: true, Validators: []*validatorReport{{Jobs: jobs}}},
+			wantEmit: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			ar := &annotationReporter{w: buf}
+			if err := ar.Report(context.Background(), tt.r); err != nil {
+				t.Fatalf("annotationReporter.Report() error = %v", err)
+			}
+			if got := buf.Len() != 0; got != tt.wantEmit {
+				t.Errorf("annotationReporter.Report() wrote %q, wantEmit %v", buf.String(), tt.wantEmit)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+const (
+	checkRunInProgressStatus = "in_progress"
+	checkRunCompletedStatus  = "completed"
+
+	// NOTE: https://docs.github.com/en/rest/reference/checks#create-a-check-run
+	maxCheckRunSummaryLength = 65535
+)
+
+// commitSHARegexp matches the full SHA of a commit, which check runs need to be created for.
+var commitSHARegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// checkRunReporter reports the verdict as a dedicated check run, which is in progress while polling,
+// and completed with success or failure at the end.
+type checkRunReporter struct {
+	client     github.Client
+	owner      string
+	repo       string
+	headSHA    string
+	name       string
+	detailsURL string
+
+	id int64 // ID of the check run, which is set once it is created
+}
+
+func newCheckRunReporter(c github.Client, owner, repo, headSHA, name string) *checkRunReporter {
+	cr := &checkRunReporter{
+		client:  c,
+		owner:   owner,
+		repo:    repo,
+		headSHA: headSHA,
+		name:    name,
+	}
+	// Link the check run to the workflow run which performs the validation.
+	server, repository, runID := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+	if len(server) != 0 && len(repository) != 0 && len(runID) != 0 {
+		cr.detailsURL = fmt.Sprintf("%s/%s/actions/runs/%s", server, repository, runID)
+	}
+	return cr
+}
+
+func (cr *checkRunReporter) Report(ctx context.Context, r *report) error {
+	status := checkRunInProgressStatus
+	var conclusion *string
+	var completedAt *github.Timestamp
+	if r.Final {
+		status = checkRunCompletedStatus
+		c := resultSuccess
+		if r.Result != resultSuccess {
+			c = resultFailure
+		}
+		conclusion = &c
+		completedAt = &github.Timestamp{Time: time.Now()}
+	}
+
+	title := fmt.Sprintf("Merge Gatekeeper: %s", r.Result)
+	summary := renderMarkdownSummary(r)
+	summary = truncateString(summary, maxCheckRunSummaryLength)
+	output := &github.CheckRunOutput{
+		Title:   &title,
+		Summary: &summary,
+	}
+
+	var detailsURL *string
+	if len(cr.detailsURL) != 0 {
+		detailsURL = &cr.detailsURL
+	}
+
+	if cr.id == 0 {
+		run, _, err := cr.client.CreateCheckRun(ctx, cr.owner, cr.repo, github.CreateCheckRunOptions{
+			Name:        cr.name,
+			HeadSHA:     cr.headSHA,
+			DetailsURL:  detailsURL,
+			Status:      &status,
+			Conclusion:  conclusion,
+			StartedAt:   &github.Timestamp{Time: time.Now()},
+			CompletedAt: completedAt,
+			Output:      output,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create check run: %w", err)
+		}
+		cr.id = run.GetID()
+		return nil
+	}
+
+	_, _, err := cr.client.UpdateCheckRun(ctx, cr.owner, cr.repo, cr.id, github.UpdateCheckRunOptions{
+		Name:        cr.name,
+		DetailsURL:  detailsURL,
+		Status:      &status,
+		Conclusion:  conclusion,
+		CompletedAt: completedAt,
+		Output:      output,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update check run: %w", err)
+	}
+	return nil
+}
+
+// resolveCommitSHA returns the SHA of the commit which the ref points to, such as a branch name or tag name.
+// The GitHub API is called only when the ref is not a full commit SHA.
+func resolveCommitSHA(ctx context.Context, c github.Client, owner, repo, ref string) (string, error) {
+	if commitSHARegexp.MatchString(ref) {
+		return ref, nil
+	}
+	sha, _, err := c.GetCommitSHA(ctx, owner, repo, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ref %q to commit SHA: %w", ref, err)
+	}
+	return sha, nil
+}
+
+// truncateString truncates s to at most n bytes, without splitting a multi-byte character.
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
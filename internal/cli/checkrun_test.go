@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_checkRunReporter_Report(t *testing.T) {
+	type call struct {
+		method     string
+		checkRunID int64
+		status     string
+		conclusion string
+		title      string
+	}
+	tests := map[string]struct {
+		reports   []*report
+		createErr error
+		wantCalls []call
+		wantErr   bool
+	}{
+		"creates in progress check run, and completes it with the verdict": {
+			reports: []*report{
+				{Result: resultPending, Poll: 1},
+				{Result: resultPending, Poll: 2},
+				{Result: resultFailure, Poll: 3, Final: true},
+			},
+			wantCalls: []call{
+				{method: "create", status: checkRunInProgressStatus, title: "Merge Gatekeeper: pending"},
+				{method: "update", checkRunID: 123, status: checkRunInProgressStatus, title: "Merge Gatekeeper: pending"},
+				{method: "update", checkRunID: 123, status: checkRunCompletedStatus, conclusion: resultFailure, title: "Merge Gatekeeper: failure"},
+			},
+		},
+		"creates completed check run when the first report is final": {
+			reports: []*report{
+				{Result: resultSuccess, Poll: 1, Final: true},
+			},
+			wantCalls: []call{
+				{method: "create", status: checkRunCompletedStatus, conclusion: resultSuccess, title: "Merge Gatekeeper: success"},
+			},
+		},
+		"returns error when check run cannot be created": {
+			reports: []*report{
+				{Result: resultPending, Poll: 1},
+			},
+			createErr: errors.New("err"),
+			wantCalls: []call{
+				{method: "create", status: checkRunInProgressStatus, title: "Merge Gatekeeper: pending"},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls []call
+			client := &mock.Client{
+				CreateCheckRunFunc: func(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+					if opts.Name != "Merge Gatekeeper" || opts.HeadSHA != "sha" {
+						t.Errorf("CreateCheckRun() name = %s, head SHA = %s", opts.Name, opts.HeadSHA)
+					}
+					calls = append(calls, call{
+						method:     "create",
+						status:     opts.GetStatus(),
+						conclusion: opts.GetConclusion(),
+						title:      opts.GetOutput().GetTitle(),
+					})
+					if tt.createErr != nil {
+						return nil, nil, tt.createErr
+					}
+					id := int64(123)
+					return &github.CheckRun{ID: &id}, nil, nil
+				},
+				UpdateCheckRunFunc: func(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+					calls = append(calls, call{
+						method:     "update",
+						checkRunID: checkRunID,
+						status:     opts.GetStatus(),
+						conclusion: opts.GetConclusion(),
+						title:      opts.GetOutput().GetTitle(),
+					})
+					return &github.CheckRun{ID: &checkRunID}, nil, nil
+				},
+			}
+			cr := newCheckRunReporter(client, "upsidr", "merge-gatekeeper", "sha", "Merge Gatekeeper")
+
+			var err error
+			for _, r := range tt.reports {
+				if err = cr.Report(context.Background(), r); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkRunReporter.Report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(calls) != len(tt.wantCalls) {
+				t.Fatalf("checkRunReporter.Report() calls = %+v, want %+v", calls, tt.wantCalls)
+			}
+			for i := range calls {
+				if calls[i] != tt.wantCalls[i] {
+					t.Errorf("checkRunReporter.Report() call[%d] = %+v, want %+v", i, calls[i], tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_resolveCommitSHA(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	tests := map[string]struct {
+		ref     string
+		client  github.Client
+		want    string
+		wantErr bool
+	}{
+		"returns ref when it is a commit SHA": {
+			ref:    sha,
+			client: &mock.Client{},
+			want:   sha,
+		},
+		"returns commit SHA of branch": {
+			ref: "main",
+			client: &mock.Client{
+				GetCommitSHAFunc: func(ctx context.Context, owner, repo, ref string) (string, *github.Response, error) {
+					if ref != "main" {
+						t.Errorf("GetCommitSHA() ref = %s, want main", ref)
+					}
+					return sha, nil, nil
+				},
+			},
+			want: sha,
+		},
+		"returns error when ref cannot be resolved": {
+			ref: "missing",
+			client: &mock.Client{
+				GetCommitSHAFunc: func(ctx context.Context, owner, repo, ref string) (string, *github.Response, error) {
+					return "", nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveCommitSHA(context.Background(), tt.client, "upsidr", "merge-gatekeeper", tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveCommitSHA() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveCommitSHA() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_truncateString(t *testing.T) {
+	tests := map[string]struct {
+		s    string
+		n    int
+		want string
+	}{
+		"returns string as is when it is short": {
+			s:    "build",
+			n:    5,
+			want: "build",
+		},
+		"truncates string by bytes": {
+			s:    "build",
+			n:    3,
+			want: "bui",
+		},
+		"does not split multi-byte character": {
+			s:    "ビルド",
+			n:    4,
+			want: "ビ",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := truncateString(tt.s, tt.n); got != tt.want {
+				t.Errorf("truncateString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
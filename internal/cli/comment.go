@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const (
+	commentMarkerFormat = "<!-- merge-gatekeeper: %s -->"
+	commentsPerPage     = 100
+)
+
+// commentJobSections is the order of the job lists in the pull request comment.
+var commentJobSections = []struct {
+	title  string
+	states []validators.JobState
+}{
+	{title: "Failed jobs", states: []validators.JobState{validators.JobStateFailure}},
+	{title: "Pending jobs", states: []validators.JobState{validators.JobStatePending, validators.JobStateMissing}},
+	{title: "Ignored jobs", states: []validators.JobState{validators.JobStateIgnored}},
+}
+
+// commentReporter posts the report as a single pull request comment, and edits it as the validation progresses.
+// The comment is found by the hidden marker, so that it is reused across runs instead of posting a new one.
+type commentReporter struct {
+	client github.Client
+	owner  string
+	repo   string
+	number int
+	marker string
+
+	id int64 // ID of the comment, which is set once it is found or created
+	// lastContent is the content of the comment last posted, to avoid editing the comment when only the footer changes.
+	lastContent string
+}
+
+func newCommentReporter(c github.Client, owner, repo string, number int, selfJobName string) *commentReporter {
+	return &commentReporter{
+		client: c,
+		owner:  owner,
+		repo:   repo,
+		number: number,
+		marker: fmt.Sprintf(commentMarkerFormat, selfJobName),
+	}
+}
+
+func (cr *commentReporter) Report(ctx context.Context, r *report) error {
+	// The footer changes on every poll, and thus it is not compared to avoid spending the quota of content-creating requests.
+	content := renderComment(r)
+	if content == cr.lastContent {
+		return nil
+	}
+	body := cr.marker + "\n" + content + renderCommentFooter(r)
+
+	if cr.id == 0 {
+		id, err := cr.findComment(ctx)
+		if err != nil {
+			return err
+		}
+		cr.id = id
+	}
+
+	if cr.id == 0 {
+		comment, _, err := cr.client.CreateIssueComment(ctx, cr.owner, cr.repo, cr.number, &github.IssueComment{Body: &body})
+		if err != nil {
+			return fmt.Errorf("failed to create pull request comment: %w", err)
+		}
+		cr.id = comment.GetID()
+	} else {
+		if _, _, err := cr.client.EditIssueComment(ctx, cr.owner, cr.repo, cr.id, &github.IssueComment{Body: &body}); err != nil {
+			return fmt.Errorf("failed to edit pull request comment: %w", err)
+		}
+	}
+	cr.lastContent = content
+	return nil
+}
+
+// findComment returns the ID of the comment with the marker, or 0 when there is no such comment.
+func (cr *commentReporter) findComment(ctx context.Context) (int64, error) {
+	var id int64
+	err := github.Paginate(github.MaxPages, "pull request comments", func(page int) (*github.Response, error) {
+		comments, resp, err := cr.client.ListIssueComments(ctx, cr.owner, cr.repo, cr.number, &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{
+				Page:    page,
+				PerPage: commentsPerPage,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request comments: %w", err)
+		}
+		for _, comment := range comments {
+			if strings.HasPrefix(comment.GetBody(), cr.marker) {
+				id = comment.GetID()
+				// The remaining pages are not fetched once the comment is found.
+				return nil, nil
+			}
+		}
+		return resp, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func renderComment(r *report) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Merge Gatekeeper\n\n")
+	fmt.Fprintf(&sb, "**Result:** %s %s\n", resultEmoji(r.Result), r.Result)
+
+	for _, section := range commentJobSections {
+		jobs := r.jobs(section.states...)
+		if len(jobs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", section.title)
+		for _, job := range jobs {
+			fmt.Fprintf(&sb, "- %s %s", jobStateEmoji(job.State), job.Name)
+			switch {
+			case len(job.IgnoreReason) != 0:
+				fmt.Fprintf(&sb, ": %s", job.IgnoreReason)
+			case job.State == validators.JobStateMissing:
+				sb.WriteString(": required job never reported")
+			}
+			if len(job.URL) != 0 {
+				fmt.Fprintf(&sb, " ([details](%s))", job.URL)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// Validators which do not check any job, such as the review validator, are explained by their details.
+	for _, vr := range r.Validators {
+		if len(vr.Detail) == 0 || vr.Succeeded {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s %s\n\n", resultEmoji(vr.result()), vr.Name)
+		sb.WriteString(renderDetailBlock(vr.Detail))
+	}
+
+	return sb.String()
+}
+
+// renderCommentFooter renders the progress of the validation, which is not compared to decide whether to edit the comment.
+func renderCommentFooter(r *report) string {
+	return fmt.Sprintf("\n_Checked %d times in %s._\n", r.Poll, time.Duration(r.ElapsedSeconds)*time.Second)
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func Test_renderComment(t *testing.T) {
+	r := &report{
+		Result:         resultFailure,
+		Final:          true,
+		Poll:           3,
+		ElapsedSeconds: 90,
+		Validators: []*validatorReport{
+			{
+				Name: "merge-gatekeeper",
+				Jobs: []*validators.Job{
+					{Name: "build", State: validators.JobStateSuccess},
+					{Name: "test", State: validators.JobStateFailure, URL: "https://example.com/test"},
+					{Name: "e2e", State: validators.JobStatePending},
+					{Name: "deploy", State: validators.JobStateMissing},
+					{Name: "lint", State: validators.JobStateIgnored, IgnoreReason: "matched ignored job pattern lint*"},
+				},
+			},
+			{
+				Name:   "pull-request-review",
+				Detail: "0 out of 1 required approvals\n",
+			},
+			{
+				Name:      "labels",
+				Succeeded: true,
+				Detail:    "0 label rule violations\n",
+			},
+		},
+	}
+	want := `## Merge Gatekeeper
+
+**Result:** :x: failure
+
+### Failed jobs
+
+- :x: test ([details](https://example.com/test))
+
+### Pending jobs
+
+- :hourglass: e2e
+- :question: deploy: required job never reported
+
+### Ignored jobs
+
+- :heavy_minus_sign: lint: matched ignored job pattern lint*
+
+### :hourglass: pull-request-review
+
+` + "```" + `
+0 out of 1 required approvals
+` + "```" + `
+
+_Checked 3 times in 1m30s._
+`
+	if got := renderComment(r) + renderCommentFooter(r); got != want {
+		t.Errorf("renderComment() didn't match\n  got:\n%s\n\n  want:\n%s", got, want)
+	}
+}
+
+func Test_commentReporter_Report(t *testing.T) {
+	const marker = "<!-- merge-gatekeeper: merge-gatekeeper -->"
+	tests := map[string]struct {
+		comments    [][]*github.IssueComment // comments per page
+		endless     bool                     // whether every page has the next page
+		reports     []*report
+		wantCreated int
+		wantEdited  []int64
+		wantPages   int
+		wantErr     bool
+	}{
+		"creates comment, and edits it only when the report changes": {
+			comments: [][]*github.IssueComment{
+				{{ID: int64Ptr(1), Body: stringPtr("LGTM")}},
+			},
+			reports: []*report{
+				{Result: resultPending, Poll: 1, ElapsedSeconds: 5},
+				{Result: resultPending, Poll: 2, ElapsedSeconds: 10},
+				{Result: resultPending, Poll: 3, ElapsedSeconds: 15},
+				{Result: resultSuccess, Poll: 4, ElapsedSeconds: 20, Final: true},
+			},
+			wantCreated: 1,
+			wantEdited:  []int64{100},
+			wantPages:   1,
+		},
+		"edits existing comment with the marker on the next page, without listing the remaining pages": {
+			comments: [][]*github.IssueComment{
+				{{ID: int64Ptr(1), Body: stringPtr("LGTM")}},
+				{{ID: int64Ptr(2), Body: stringPtr(marker + "\nold")}},
+				{{ID: int64Ptr(3), Body: stringPtr("thanks")}},
+			},
+			reports: []*report{
+				{Result: resultSuccess, Poll: 1, Final: true},
+			},
+			wantCreated: 0,
+			wantEdited:  []int64{2},
+			wantPages:   2,
+		},
+		"creates comment when no page has the marker": {
+			comments: [][]*github.IssueComment{
+				{{ID: int64Ptr(1), Body: stringPtr("LGTM")}},
+				{{ID: int64Ptr(2), Body: stringPtr("thanks")}},
+				{{ID: int64Ptr(3), Body: stringPtr("merged")}},
+			},
+			reports: []*report{
+				{Result: resultSuccess, Poll: 1, Final: true},
+			},
+			wantCreated: 1,
+			wantPages:   3,
+		},
+		"returns error when the pages never end": {
+			comments: [][]*github.IssueComment{
+				{{ID: int64Ptr(1), Body: stringPtr("LGTM")}},
+			},
+			endless: true,
+			reports: []*report{
+				{Result: resultSuccess, Poll: 1, Final: true},
+			},
+			wantPages: github.MaxPages,
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var created, pages int
+			var edited []int64
+			client := &mock.Client{
+				ListIssueCommentsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+					pages++
+					if tt.endless {
+						return tt.comments[0], &github.Response{NextPage: opts.Page + 1}, nil
+					}
+					resp := &github.Response{}
+					if opts.Page < len(tt.comments) {
+						resp.NextPage = opts.Page + 1
+					}
+					return tt.comments[opts.Page-1], resp, nil
+				},
+				CreateIssueCommentFunc: func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+					if number != 42 || !strings.HasPrefix(comment.GetBody(), marker) {
+						t.Errorf("CreateIssueComment() number = %d, body = %q", number, comment.GetBody())
+					}
+					created++
+					return &github.IssueComment{ID: int64Ptr(100)}, nil, nil
+				},
+				EditIssueCommentFunc: func(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+					if !strings.HasPrefix(comment.GetBody(), marker) {
+						t.Errorf("EditIssueComment() body = %q", comment.GetBody())
+					}
+					edited = append(edited, commentID)
+					return comment, nil, nil
+				},
+			}
+			cr := newCommentReporter(client, "upsidr", "merge-gatekeeper", 42, "merge-gatekeeper")
+			for _, r := range tt.reports {
+				if err := cr.Report(context.Background(), r); (err != nil) != tt.wantErr {
+					t.Fatalf("commentReporter.Report() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if pages != tt.wantPages {
+				t.Errorf("commentReporter.Report() listed %d pages of comments, want %d", pages, tt.wantPages)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("commentReporter.Report() created %d comments, want %d", created, tt.wantCreated)
+			}
+			if len(edited) != len(tt.wantEdited) {
+				t.Fatalf("commentReporter.Report() edited %v, want %v", edited, tt.wantEdited)
+			}
+			for i := range edited {
+				if edited[i] != tt.wantEdited[i] {
+					t.Errorf("commentReporter.Report() edited %v, want %v", edited, tt.wantEdited)
+				}
+			}
+		})
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
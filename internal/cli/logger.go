@@ -1,5 +1,7 @@
 package cli
 
+import "io"
+
 type logger interface {
 	Print(i ...interface{})
 	Println(i ...interface{})
@@ -7,4 +9,23 @@ type logger interface {
 	PrintErr(i ...interface{})
 	PrintErrln(i ...interface{})
 	PrintErrf(format string, i ...interface{})
+	OutOrStdout() io.Writer
+	ErrOrStderr() io.Writer
+}
+
+// stderrLogger writes all the logs to stderr, so that stdout is kept for machine-readable output.
+type stderrLogger struct {
+	logger
+}
+
+func (l *stderrLogger) Print(i ...interface{}) {
+	l.PrintErr(i...)
+}
+
+func (l *stderrLogger) Println(i ...interface{}) {
+	l.PrintErrln(i...)
+}
+
+func (l *stderrLogger) Printf(format string, i ...interface{}) {
+	l.PrintErrf(format, i...)
 }
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const outputEnv = "GITHUB_OUTPUT"
+
+// outputReporter writes the final report as step outputs of GitHub Actions.
+// Job lists are written as JSON arrays, because job names may contain commas, and can be read with fromJSON.
+// NOTE: https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-output-parameter
+type outputReporter struct {
+	path string
+}
+
+func (or *outputReporter) Report(_ context.Context, r *report) error {
+	if !r.Final {
+		return nil
+	}
+
+	outputs, err := stepOutputs(r)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(or.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(outputs)
+	return err
+}
+
+func stepOutputs(r *report) (string, error) {
+	jobLists := []struct {
+		name   string
+		states []validators.JobState
+	}{
+		{name: "failed_jobs", states: []validators.JobState{validators.JobStateFailure}},
+		{name: "pending_jobs", states: []validators.JobState{validators.JobStatePending, validators.JobStateMissing}},
+		{name: "ignored_jobs", states: []validators.JobState{validators.JobStateIgnored}},
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "result=%s\n", r.Result)
+	for _, l := range jobLists {
+		names := []string{}
+		for _, job := range r.jobs(l.states...) {
+			names = append(names, job.Name)
+		}
+		b, err := json.Marshal(names)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "%s=%s\n", l.name, b)
+	}
+	b, err := json.Marshal(r.validatorNames(resultFailure, resultPending))
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&sb, "unsucceeded_validators=%s\n", b)
+	fmt.Fprintf(&sb, "elapsed_seconds=%d\n", r.ElapsedSeconds)
+	return sb.String(), nil
+}
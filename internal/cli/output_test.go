@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func Test_stepOutputs(t *testing.T) {
+	tests := map[string]struct {
+		r    *report
+		want string
+	}{
+		"returns outputs with job lists": {
+			r: &report{
+				Result:         resultFailure,
+				Final:          true,
+				ElapsedSeconds: 42,
+				Validators: []*validatorReport{
+					{
+						Name: "merge-gatekeeper",
+						Jobs: []*validators.Job{
+							{Name: "build", State: validators.JobStateSuccess},
+							{Name: "test (ubuntu-latest, 1.21)", State: validators.JobStateFailure},
+							{Name: "e2e", State: validators.JobStatePending},
+							{Name: "deploy-*", State: validators.JobStateMissing},
+							{Name: "lint", State: validators.JobStateIgnored},
+						},
+					},
+					{
+						Name:   "pull-request-review",
+						Detail: "0 out of 1 required approvals",
+					},
+				},
+			},
+			want: `result=failure
+failed_jobs=["test (ubuntu-latest, 1.21)"]
+pending_jobs=["e2e","deploy-*"]
+ignored_jobs=["lint"]
+unsucceeded_validators=["merge-gatekeeper","pull-request-review"]
+elapsed_seconds=42
+`,
+		},
+		"returns outputs with empty job lists": {
+			r: &report{
+				Result: resultSuccess,
+				Final:  true,
+			},
+			want: `result=success
+failed_jobs=[]
+pending_jobs=[]
+ignored_jobs=[]
+unsucceeded_validators=[]
+elapsed_seconds=0
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := stepOutputs(tt.r)
+			if err != nil {
+				t.Fatalf("stepOutputs() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("stepOutputs() didn't match\n  got:\n%s\n\n  want:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputReporter_Report(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	or := &outputReporter{path: path}
+
+	pending := &report{Result: resultPending, Poll: 1}
+	final := &report{Result: resultSuccess, Final: true, Poll: 2}
+	for _, r := range []*report{pending, final} {
+		if err := or.Report(context.Background(), r); err != nil {
+			t.Fatalf("outputReporter.Report() error = %v", err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	want, _ := stepOutputs(final)
+	if got := string(b); got != want {
+		t.Errorf("outputReporter.Report() file didn't match\n  got:\n%s\n\n  want:\n%s", got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+	resultPending = "pending"
+)
+
+// validationResult holds the latest result of a validator.
+type validationResult struct {
+	name   string
+	status validators.Status // may be nil when the validation failed with an error
+	err    error
+}
+
+// report is the machine-readable result of the validation, which is generated per poll and at the end.
+type report struct {
+	Result         string             `json:"result"`
+	Final          bool               `json:"final"`
+	Poll           int                `json:"poll"`
+	ElapsedSeconds int                `json:"elapsed_seconds"`
+	Error          string             `json:"error,omitempty"`
+	Validators     []*validatorReport `json:"validators"`
+}
+
+type validatorReport struct {
+	Name      string                      `json:"name"`
+	Succeeded bool                        `json:"succeeded"`
+	Counts    map[validators.JobState]int `json:"counts"`
+	Jobs      []*validators.Job           `json:"jobs"`
+	// Detail is the detail of the status for validators which do not check any job, such as the review validator,
+	// so that the reason why the validator blocks the merge can be reported.
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// result returns the verdict of the validator.
+func (vr *validatorReport) result() string {
+	switch {
+	case vr.Succeeded:
+		return resultSuccess
+	case len(vr.Error) != 0:
+		return resultFailure
+	default:
+		return resultPending
+	}
+}
+
+func newReport(results []*validationResult, poll int, elapsed time.Duration, final bool, err error) *report {
+	r := &report{
+		Result:         resultPending,
+		Final:          final,
+		Poll:           poll,
+		ElapsedSeconds: int(elapsed.Seconds()),
+		Validators:     make([]*validatorReport, 0, len(results)),
+	}
+	switch {
+	case err != nil:
+		r.Result = resultFailure
+		r.Error = err.Error()
+	case final:
+		r.Result = resultSuccess
+	}
+
+	for _, res := range results {
+		if res == nil {
+			continue
+		}
+		vr := &validatorReport{
+			Name:   res.name,
+			Counts: make(map[validators.JobState]int),
+			Jobs:   []*validators.Job{},
+		}
+		if res.status != nil {
+			vr.Succeeded = res.status.IsSuccess() && res.err == nil
+			if jobs := res.status.Jobs(); jobs != nil {
+				vr.Jobs = jobs
+			} else {
+				vr.Detail = res.status.Detail()
+			}
+			for _, job := range vr.Jobs {
+				vr.Counts[job.State]++
+			}
+		}
+		if res.err != nil {
+			vr.Error = res.err.Error()
+		}
+		r.Validators = append(r.Validators, vr)
+	}
+	return r
+}
+
+// jobs returns all the jobs in the report with the given states, or all the jobs when no state is given.
+func (r *report) jobs(states ...validators.JobState) []*validators.Job {
+	var jobs []*validators.Job
+	for _, vr := range r.Validators {
+		for _, job := range vr.Jobs {
+			if len(states) == 0 {
+				jobs = append(jobs, job)
+				continue
+			}
+			for _, state := range states {
+				if job.State == state {
+					jobs = append(jobs, job)
+					break
+				}
+			}
+		}
+	}
+	return jobs
+}
+
+// validatorNames returns the names of the validators with the given verdicts.
+func (r *report) validatorNames(results ...string) []string {
+	names := []string{}
+	for _, vr := range r.Validators {
+		for _, result := range results {
+			if vr.result() == result {
+				names = append(names, vr.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+type reporter interface {
+	Report(ctx context.Context, r *report) error
+}
+
+type reporters []reporter
+
+func newReporters(logger logger) (reporters, error) {
+	var rs reporters
+	switch outputFormat {
+	case outputFormatText:
+	case outputFormatJSON:
+		rs = append(rs, &jsonReporter{w: logger.OutOrStdout()})
+	default:
+		return nil, fmt.Errorf("output format %q is invalid, it must be either %s or %s", outputFormat, outputFormatText, outputFormatJSON)
+	}
+	if len(outputFile) != 0 {
+		rs = append(rs, &jsonFileReporter{path: outputFile})
+	}
+	if path := os.Getenv(stepSummaryEnv); stepSummary && len(path) != 0 {
+		rs = append(rs, &stepSummaryReporter{path: path})
+	}
+	if annotations && os.Getenv(githubActionsEnv) == "true" {
+		rs = append(rs, &annotationReporter{w: logger.ErrOrStderr()})
+	}
+	if path := os.Getenv(outputEnv); len(path) != 0 {
+		rs = append(rs, &outputReporter{path: path})
+	}
+	return rs, nil
+}
+
+// report sends the report to all the reporters.
+// Failing to report does not fail the validation, and is only logged.
+func (rs reporters) report(ctx context.Context, logger logger, r *report) {
+	for _, rp := range rs {
+		if err := rp.Report(ctx, r); err != nil {
+			logger.PrintErrf("  WARNING: Failed to report the validation result: %v\n", err)
+		}
+	}
+}
+
+// jsonReporter writes a JSON document per line for each report.
+type jsonReporter struct {
+	w io.Writer
+}
+
+func (jr *jsonReporter) Report(_ context.Context, r *report) error {
+	return json.NewEncoder(jr.w).Encode(r)
+}
+
+// jsonFileReporter writes the latest report to the file as a JSON document,
+// so that the file holds the final verdict once the validation is done.
+type jsonFileReporter struct {
+	path string
+}
+
+func (fr *jsonFileReporter) Report(_ context.Context, r *report) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fr.path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_newReport(t *testing.T) {
+	jobs := []*validators.Job{
+		{Name: "job-01", State: validators.JobStateSuccess},
+		{Name: "job-02", State: validators.JobStateFailure},
+		{Name: "job-03", State: validators.JobStatePending},
+		{Name: "job-04", State: validators.JobStatePending},
+	}
+	st := &mock.Status{
+		IsSuccessFunc: func() bool { return false },
+		JobsFunc:      func() []*validators.Job { return jobs },
+	}
+
+	tests := map[string]struct {
+		results []*validationResult
+		final   bool
+		err     error
+		want    *report
+	}{
+		"returns pending report while polling": {
+			results: []*validationResult{
+				{name: "validator-1", status: st},
+			},
+			final: false,
+			want: &report{
+				Result:         resultPending,
+				Poll:           2,
+				ElapsedSeconds: 30,
+				Validators: []*validatorReport{
+					{
+						Name: "validator-1",
+						Counts: map[validators.JobState]int{
+							validators.JobStateSuccess: 1,
+							validators.JobStateFailure: 1,
+							validators.JobStatePending: 2,
+						},
+						Jobs: jobs,
+					},
+				},
+			},
+		},
+		"returns failure report with the error": {
+			results: []*validationResult{
+				{name: "validator-1", status: st, err: errors.New("err")},
+				nil, // The validator which has not run yet
+			},
+			final: true,
+			err:   errors.New("err"),
+			want: &report{
+				Result:         resultFailure,
+				Final:          true,
+				Poll:           2,
+				ElapsedSeconds: 30,
+				Error:          "err",
+				Validators: []*validatorReport{
+					{
+						Name: "validator-1",
+						Counts: map[validators.JobState]int{
+							validators.JobStateSuccess: 1,
+							validators.JobStateFailure: 1,
+							validators.JobStatePending: 2,
+						},
+						Jobs:  jobs,
+						Error: "err",
+					},
+				},
+			},
+		},
+		"returns success report with detail of validators without jobs": {
+			results: []*validationResult{
+				{name: "validator-1", status: &mock.Status{
+					DetailFunc:    func() string { return "2 out of 2 required approvals" },
+					IsSuccessFunc: func() bool { return true },
+					JobsFunc:      func() []*validators.Job { return nil },
+				}},
+			},
+			final: true,
+			want: &report{
+				Result:         resultSuccess,
+				Final:          true,
+				Poll:           2,
+				ElapsedSeconds: 30,
+				Validators: []*validatorReport{
+					{
+						Name:      "validator-1",
+						Succeeded: true,
+						Counts:    map[validators.JobState]int{},
+						Jobs:      []*validators.Job{},
+						Detail:    "2 out of 2 required approvals",
+					},
+				},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := newReport(tt.results, 2, 30*time.Second, tt.final, tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newReport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_jsonReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	jr := &jsonReporter{w: &buf}
+	for _, r := range []*report{
+		{Result: resultPending, Poll: 1},
+		{Result: resultSuccess, Poll: 2, Final: true},
+	} {
+		if err := jr.Report(context.Background(), r); err != nil {
+			t.Fatalf("jsonReporter.Report() error = %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("jsonReporter.Report() wrote %d lines, want 2", len(lines))
+	}
+	var got report
+	if err := json.Unmarshal([]byte(lines[1]), &got); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	if got.Result != resultSuccess || !got.Final {
+		t.Errorf("jsonReporter.Report() last report = %+v, want final success", got)
+	}
+}
+
+func Test_jsonFileReporter_Report(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.json")
+	fr := &jsonFileReporter{path: path}
+	for _, r := range []*report{
+		{Result: resultPending, Poll: 1},
+		{Result: resultFailure, Poll: 2, Final: true, Error: "err"},
+	} {
+		if err := fr.Report(context.Background(), r); err != nil {
+			t.Fatalf("jsonFileReporter.Report() error = %v", err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	var got report
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	if got.Result != resultFailure || got.Error != "err" {
+		t.Errorf("jsonFileReporter.Report() file holds %+v, want the last report", got)
+	}
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const stepSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+// summaryJobStates is the order of the job counts in the summary.
+var summaryJobStates = []validators.JobState{
+	validators.JobStateSuccess,
+	validators.JobStateFailure,
+	validators.JobStatePending,
+	validators.JobStateIgnored,
+	validators.JobStateMissing,
+	validators.JobStateSuperseded,
+}
+
+// stepSummaryReporter appends the final report as Markdown to the job summary of GitHub Actions.
+// NOTE: https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#adding-a-job-summary
+type stepSummaryReporter struct {
+	path string
+}
+
+func (sr *stepSummaryReporter) Report(_ context.Context, r *report) error {
+	if !r.Final {
+		return nil
+	}
+
+	f, err := os.OpenFile(sr.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(renderMarkdownSummary(r))
+	return err
+}
+
+func renderMarkdownSummary(r *report) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Merge Gatekeeper\n\n")
+	fmt.Fprintf(&sb, "**Result:** %s %s\n\n", resultEmoji(r.Result), r.Result)
+	fmt.Fprintf(&sb, "Checked %d times in %s.\n", r.Poll, time.Duration(r.ElapsedSeconds)*time.Second)
+
+	for _, vr := range r.Validators {
+		fmt.Fprintf(&sb, "\n### %s\n\n", escapeMarkdownTableCell(vr.Name))
+		fmt.Fprintf(&sb, "**Verdict:** %s %s\n\n", resultEmoji(vr.result()), vr.result())
+
+		// Validators which do not check any job explain their verdicts by the details instead of the job table.
+		if len(vr.Detail) != 0 {
+			sb.WriteString(renderDetailBlock(vr.Detail))
+			continue
+		}
+
+		header := make([]string, 0, len(summaryJobStates))
+		counts := make([]string, 0, len(summaryJobStates))
+		for _, state := range summaryJobStates {
+			header = append(header, string(state))
+			counts = append(counts, fmt.Sprintf("%d", vr.Counts[state]))
+		}
+		fmt.Fprintf(&sb, "| %s |\n", strings.Join(header, " | "))
+		fmt.Fprintf(&sb, "|%s\n", strings.Repeat(" ---: |", len(header)))
+		fmt.Fprintf(&sb, "| %s |\n", strings.Join(counts, " | "))
+
+		if len(vr.Jobs) == 0 {
+			continue
+		}
+
+		sb.WriteString("\n| Job | State | Duration | Link |\n")
+		sb.WriteString("| --- | --- | ---: | --- |\n")
+		for _, job := range vr.Jobs {
+			link := "-"
+			if len(job.URL) != 0 {
+				link = fmt.Sprintf("[details](%s)", job.URL)
+			}
+			fmt.Fprintf(&sb, "| %s | %s %s | %s | %s |\n",
+				escapeMarkdownTableCell(job.Name),
+				jobStateEmoji(job.State), job.State,
+				jobDuration(job),
+				link,
+			)
+		}
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// renderDetailBlock renders the detail of the validator as a code block.
+// The group commands of GitHub Actions in the detail are replaced with plain titles, because they are only meaningful in logs.
+func renderDetailBlock(detail string) string {
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	for _, line := range strings.Split(strings.TrimRight(detail, "\n"), "\n") {
+		switch {
+		case line == "::endgroup::":
+			continue
+		case strings.HasPrefix(line, "::group::"):
+			line = strings.TrimPrefix(line, "::group::") + ":"
+		}
+		sb.WriteString(strings.ReplaceAll(line, "```", "'''"))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+func jobDuration(job *validators.Job) string {
+	if job.StartedAt == nil || job.CompletedAt == nil {
+		return "-"
+	}
+	return job.CompletedAt.Sub(*job.StartedAt).Round(time.Second).String()
+}
+
+func escapeMarkdownTableCell(str string) string {
+	return strings.ReplaceAll(str, "|", `\|`)
+}
+
+func resultEmoji(result string) string {
+	switch result {
+	case resultSuccess:
+		return ":white_check_mark:"
+	case resultFailure:
+		return ":x:"
+	default:
+		return ":hourglass:"
+	}
+}
+
+func jobStateEmoji(state validators.JobState) string {
+	switch state {
+	case validators.JobStateSuccess:
+		return ":white_check_mark:"
+	case validators.JobStateFailure:
+		return ":x:"
+	case validators.JobStatePending:
+		return ":hourglass:"
+	case validators.JobStateMissing:
+		return ":question:"
+	default:
+		return ":heavy_minus_sign:"
+	}
+}
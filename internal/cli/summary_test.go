@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func Test_renderMarkdownSummary(t *testing.T) {
+	startedAt := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		r    *report
+		want string
+	}{
+		"renders counts and jobs of each validator": {
+			r: &report{
+				Result:         resultFailure,
+				Final:          true,
+				Poll:           3,
+				ElapsedSeconds: 95,
+				Validators: []*validatorReport{
+					{
+						Name: "merge-gatekeeper",
+						Counts: map[validators.JobState]int{
+							validators.JobStateSuccess: 1,
+							validators.JobStateFailure: 1,
+						},
+						Error: "validation failed",
+						Jobs: []*validators.Job{
+							{
+								Name:        "build",
+								State:       validators.JobStateSuccess,
+								URL:         "https://github.com/upsidr/merge-gatekeeper/runs/1",
+								StartedAt:   timePtr(startedAt),
+								CompletedAt: timePtr(startedAt.Add(83 * time.Second)),
+							},
+							{
+								Name:  "test | unit",
+								State: validators.JobStateFailure,
+							},
+						},
+					},
+				},
+			},
+			want: `## Merge Gatekeeper
+
+**Result:** :x: failure
+
+Checked 3 times in 1m35s.
+
+### merge-gatekeeper
+
+**Verdict:** :x: failure
+
+| success | failure | pending | ignored | missing | superseded |
+| ---: | ---: | ---: | ---: | ---: | ---: |
+| 1 | 1 | 0 | 0 | 0 | 0 |
+
+| Job | State | Duration | Link |
+| --- | --- | ---: | --- |
+| build | :white_check_mark: success | 1m23s | [details](https://github.com/upsidr/merge-gatekeeper/runs/1) |
+| test \| unit | :x: failure | - | - |
+
+`,
+		},
+		"renders only counts when there is no job": {
+			r: &report{
+				Result:         resultSuccess,
+				Final:          true,
+				Poll:           1,
+				ElapsedSeconds: 0,
+				Validators: []*validatorReport{
+					{
+						Name:      "merge-gatekeeper",
+						Succeeded: true,
+						Counts:    map[validators.JobState]int{},
+					},
+				},
+			},
+			want: `## Merge Gatekeeper
+
+**Result:** :white_check_mark: success
+
+Checked 1 times in 0s.
+
+### merge-gatekeeper
+
+**Verdict:** :white_check_mark: success
+
+| success | failure | pending | ignored | missing | superseded |
+| ---: | ---: | ---: | ---: | ---: | ---: |
+| 0 | 0 | 0 | 0 | 0 | 0 |
+
+`,
+		},
+		"renders detail of validators without jobs": {
+			r: &report{
+				Result:         resultFailure,
+				Final:          true,
+				Poll:           2,
+				ElapsedSeconds: 10,
+				Validators: []*validatorReport{
+					{
+						Name:   "labels",
+						Counts: map[validators.JobState]int{},
+						Detail: `1 label rule violations
+
+::group::Violations
+- forbidden label "do-not-merge" is present
+::endgroup::
+`,
+					},
+				},
+			},
+			want: `## Merge Gatekeeper
+
+**Result:** :x: failure
+
+Checked 2 times in 10s.
+
+### labels
+
+**Verdict:** :hourglass: pending
+
+` + "```" + `
+1 label rule violations
+
+Violations:
+- forbidden label "do-not-merge" is present
+` + "```" + `
+
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := renderMarkdownSummary(tt.r)
+			if got != tt.want {
+				t.Errorf("renderMarkdownSummary() didn't match\n  got:\n%s\n\n  want:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stepSummaryReporter_Report(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	if err := os.WriteFile(path, []byte("existing\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	sr := &stepSummaryReporter{path: path}
+	pending := &report{Result: resultPending, Poll: 1}
+	final := &report{Result: resultSuccess, Final: true, Poll: 2}
+	for _, r := range []*report{pending, final} {
+		if err := sr.Report(context.Background(), r); err != nil {
+			t.Fatalf("stepSummaryReporter.Report() error = %v", err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if got, want := string(b), "existing\n"+renderMarkdownSummary(final); got != want {
+		t.Errorf("stepSummaryReporter.Report() file didn't match\n  got:\n%s\n\n  want:\n%s", got, want)
+	}
+}
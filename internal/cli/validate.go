@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -10,21 +11,74 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
 	"github.com/upsidr/merge-gatekeeper/internal/ticker"
 	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/codeowners"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/commitlint"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/convention"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/labels"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/review"
 	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
 )
 
-const defaultSelfJobName = "merge-gatekeeper"
+const (
+	defaultSelfJobName = "merge-gatekeeper"
+	appPrivateKeyEnv   = "GITHUB_APP_PRIVATE_KEY"
+)
 
 // These variables will be set by command line flags.
 var (
 	ghRepo              string // e.g) upsidr/merge-gatekeeper
 	ghRef               string
+	ghAPIURL            string
+	ghUploadURL         string
+	caBundle            string
+	proxyURL            string
+	appID               int64
+	appPrivateKeyFile   string
+	appInstallationID   int64
 	timeoutSecond       uint
 	validateInvalSecond uint
+	maxInvalSecond      uint
+	invalMultiplier     float64
+	invalJitter         float64
 	selfJobName         string
 	ignoredJobs         string
+	requiredJobs        string
+	minJobs             uint
+	settleTimeSecond    uint
+	conclusionPolicies  string
+	waitOnFailureSecond uint
+	outputFormat        = outputFormatText
+	outputFile          string
+	stepSummary         bool
+	annotations         bool
+	checkRunName        string
+	prComment           bool
+	prNumber            uint
+	minApprovals        uint
+	approvalAfterPush   bool
+	codeOwners          bool
+	requiredLabels      string
+	forbiddenLabels     string
+	oneOfLabels         string
+	titlePattern        string
+	conventionalTitle   bool
+	titleTypes          string
+	titleScopes         string
+	titleAllowBreaking  bool
+	bodyHeadings        string
+	bodyChecklist       string
+	bodyAllChecked      bool
+	commitSubjectMax    uint
+	commitSubjectRegexp string
+	commitNoWIP         bool
+	commitIssueTrailers string
+	includedApps        string
+	excludedApps        string
+	includedCreators    string
+	excludedCreators    string
 )
 
 func validateCmd() *cobra.Command {
@@ -36,6 +90,9 @@ func validateCmd() *cobra.Command {
 			if len(str) != 0 {
 				ghRepo = str
 			}
+			if str := os.Getenv("GITHUB_API_URL"); len(str) != 0 && len(ghAPIURL) == 0 {
+				ghAPIURL = str
+			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
@@ -45,18 +102,151 @@ func validateCmd() *cobra.Command {
 				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
 			}
 
-			statusValidator, err := status.CreateValidator(github.NewClient(ctx, ghToken),
+			cache := github.NewResponseCache()
+			ghOpts := []github.Option{
+				github.WithResponseCache(cache),
+				github.WithEnterpriseURLs(ghAPIURL, ghUploadURL),
+				github.WithCABundle(caBundle),
+				github.WithProxy(proxyURL),
+				github.WithLogger(cmd),
+			}
+			switch {
+			case appID != 0:
+				key, err := appPrivateKey()
+				if err != nil {
+					return err
+				}
+				ghOpts = append(ghOpts, github.WithApp(github.AppConfig{
+					ID:             appID,
+					PrivateKey:     key,
+					InstallationID: appInstallationID,
+					Owner:          owner,
+					Repo:           repo,
+				}))
+			case len(ghToken) == 0:
+				return errors.New("github token or GitHub App ID is required")
+			}
+			c, err := github.NewClient(ctx, ghToken, ghOpts...)
+			if err != nil {
+				return fmt.Errorf("failed to create github client: %w", err)
+			}
+			ghc := github.NewRetryClient(c, cmd)
+			statusValidator, err := status.CreateValidator(ghc,
 				status.WithSelfJob(selfJobName),
+				status.WithSelfCheckRun(checkRunName),
 				status.WithGitHubOwnerAndRepo(owner, repo),
 				status.WithGitHubRef(ghRef),
 				status.WithIgnoredJobs(ignoredJobs),
+				status.WithRequiredJobs(requiredJobs),
+				status.WithMinimumJobs(minJobs),
+				status.WithSettleTime(time.Duration(settleTimeSecond)*time.Second),
+				status.WithConclusionPolicies(conclusionPolicies),
+				status.WithFailureWindow(time.Duration(waitOnFailureSecond)*time.Second),
+				status.WithCheckRunApps(includedApps),
+				status.WithExcludedCheckRunApps(excludedApps),
+				status.WithStatusCreators(includedCreators),
+				status.WithExcludedStatusCreators(excludedCreators),
 			)
 			if err != nil {
 				return fmt.Errorf("failed to create validator: %w", err)
 			}
+			vs := []validators.Validator{statusValidator}
+
+			if minApprovals != 0 {
+				reviewValidator, err := review.CreateValidator(ghc,
+					review.WithGitHubOwnerAndRepo(owner, repo),
+					review.WithPullRequestNumber(prNumber),
+					review.WithMinimumApprovals(minApprovals),
+					review.WithApprovalAfterLastPush(approvalAfterPush),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create review validator: %w", err)
+				}
+				vs = append(vs, reviewValidator)
+			}
+
+			if codeOwners {
+				codeOwnersValidator, err := codeowners.CreateValidator(ghc,
+					codeowners.WithGitHubOwnerAndRepo(owner, repo),
+					codeowners.WithPullRequestNumber(prNumber),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create code owners validator: %w", err)
+				}
+				vs = append(vs, codeOwnersValidator)
+			}
+
+			if len(requiredLabels) != 0 || len(forbiddenLabels) != 0 || len(oneOfLabels) != 0 {
+				labelsValidator, err := labels.CreateValidator(ghc,
+					labels.WithGitHubOwnerAndRepo(owner, repo),
+					labels.WithPullRequestNumber(prNumber),
+					labels.WithRequiredLabels(requiredLabels),
+					labels.WithForbiddenLabels(forbiddenLabels),
+					labels.WithOneOfLabels(oneOfLabels),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create labels validator: %w", err)
+				}
+				vs = append(vs, labelsValidator)
+			}
+
+			if len(titlePattern) != 0 || conventionalTitle || len(titleTypes) != 0 || len(titleScopes) != 0 || len(bodyHeadings) != 0 || len(bodyChecklist) != 0 || bodyAllChecked {
+				conventionValidator, err := convention.CreateValidator(ghc,
+					convention.WithGitHubOwnerAndRepo(owner, repo),
+					convention.WithPullRequestNumber(prNumber),
+					convention.WithTitlePattern(titlePattern),
+					convention.WithConventionalTitle(conventionalTitle),
+					convention.WithTitleTypes(titleTypes),
+					convention.WithTitleScopes(titleScopes),
+					convention.WithBreakingChangeMarker(titleAllowBreaking),
+					convention.WithRequiredHeadings(bodyHeadings),
+					convention.WithRequiredChecklistItems(bodyChecklist),
+					convention.WithAllChecklistItemsTicked(bodyAllChecked),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create convention validator: %w", err)
+				}
+				vs = append(vs, conventionValidator)
+			}
+
+			if commitSubjectMax != 0 || len(commitSubjectRegexp) != 0 || commitNoWIP || len(commitIssueTrailers) != 0 {
+				commitLintValidator, err := commitlint.CreateValidator(ghc,
+					commitlint.WithGitHubOwnerAndRepo(owner, repo),
+					commitlint.WithPullRequestNumber(prNumber),
+					commitlint.WithMaxSubjectLength(commitSubjectMax),
+					commitlint.WithSubjectPattern(commitSubjectRegexp),
+					commitlint.WithNoWorkInProgress(commitNoWIP),
+					commitlint.WithIssueTrailers(commitIssueTrailers),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create commit lint validator: %w", err)
+				}
+				vs = append(vs, commitLintValidator)
+			}
+
+			rs, err := newReporters(cmd)
+			if err != nil {
+				return err
+			}
+			rs = append(rs, &cacheStatsReporter{cache: cache, logger: cmd})
+			if len(checkRunName) != 0 {
+				// Check runs can only be created for commit SHAs, and thus the ref is resolved once at startup.
+				headSHA, err := resolveCommitSHA(ctx, ghc, owner, repo, ghRef)
+				if err != nil {
+					return err
+				}
+				rs = append(rs, newCheckRunReporter(ghc, owner, repo, headSHA, checkRunName))
+			}
+			if prComment {
+				if prNumber == 0 {
+					cmd.PrintErrln("WARNING: Pull request comment is disabled, because pull request number is not set.")
+				} else {
+					rs = append(rs, newCommentReporter(ghc, owner, repo, int(prNumber), selfJobName))
+				}
+			}
 
 			cmd.SilenceUsage = true
-			return doValidateCmd(ctx, cmd, statusValidator)
+			return doValidateCmd(ctx, cmd, rs, vs...)
 		},
 	}
 
@@ -67,14 +257,85 @@ func validateCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name")
 	cmd.MarkPersistentFlagRequired("ref")
 
+	cmd.PersistentFlags().StringVar(&ghAPIURL, "api-url", "", "set GitHub API URL, such as https://github.example.com/api/v3 for GitHub Enterprise Server (defaults to GITHUB_API_URL)")
+	cmd.PersistentFlags().StringVar(&ghUploadURL, "upload-url", "", "set GitHub upload URL for GitHub Enterprise Server (derived from api-url by default)")
+	cmd.PersistentFlags().StringVar(&caBundle, "ca-bundle", "", "set path of PEM file with CA certificates to trust in addition to the system ones")
+	cmd.PersistentFlags().Int64Var(&appID, "app-id", 0, "set GitHub App ID to authenticate as its installation instead of the token")
+	cmd.PersistentFlags().StringVar(&appPrivateKeyFile, "app-private-key-file", "", "set path of the private key of GitHub App (defaults to the key in "+appPrivateKeyEnv+")")
+	cmd.PersistentFlags().Int64Var(&appInstallationID, "app-installation-id", 0, "set installation ID of GitHub App (found by the repository by default)")
+
+	cmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "set proxy URL (defaults to HTTPS_PROXY and HTTP_PROXY)")
+
 	cmd.PersistentFlags().UintVar(&timeoutSecond, "timeout", 600, "set validate timeout second")
 	cmd.PersistentFlags().UintVar(&validateInvalSecond, "interval", 10, "set validate interval second")
+	cmd.PersistentFlags().UintVar(&maxInvalSecond, "max-interval", 0, "set max validate interval second for backoff (0 is the same as interval)")
+	cmd.PersistentFlags().Float64Var(&invalMultiplier, "interval-multiplier", 1, "set multiplier of validate interval for backoff, which requires max-interval greater than interval (1 keeps interval)")
+	cmd.PersistentFlags().Float64Var(&invalJitter, "interval-jitter", 0, "set jitter of validate interval as a ratio between 0 and 1, such as 0.1 for ±10%")
+
+	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs (comma-separated list of job names, glob patterns, or regular expressions prefixed with 're:')")
+
+	cmd.PersistentFlags().StringVar(&requiredJobs, "required", "", "set required jobs which must be reported and succeed (comma-separated list of job names, glob patterns, or regular expressions prefixed with 're:')")
 
-	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs (comma-separated list)")
+	cmd.PersistentFlags().UintVar(&minJobs, "min-jobs", 0, "set minimum number of jobs, excluding itself and ignored jobs, to be reported before the validation can succeed")
+	cmd.PersistentFlags().UintVar(&settleTimeSecond, "settle-time", 0, "set grace period second to wait for other jobs to be reported before the validation can succeed")
+
+	cmd.PersistentFlags().StringVar(&conclusionPolicies, "conclusion-policy", "", "set how check runs are treated based on their conclusions (comma-separated list of conclusion=policy, where policy is one of success, failure, pending or ignore)")
+
+	cmd.PersistentFlags().UintVar(&waitOnFailureSecond, "wait-on-failure", 0, "set window second to keep waiting for failed jobs to be re-run instead of failing immediately (0 disables waiting)")
+
+	cmd.PersistentFlags().StringVar(&includedApps, "include-apps", "", "set GitHub Apps whose check runs are validated (comma-separated list of app slugs or IDs)")
+	cmd.PersistentFlags().StringVar(&excludedApps, "exclude-apps", "", "set GitHub Apps whose check runs are not validated (comma-separated list of app slugs or IDs)")
+	cmd.PersistentFlags().StringVar(&includedCreators, "include-status-creators", "", "set users whose commit statuses are validated (comma-separated list of user logins)")
+	cmd.PersistentFlags().StringVar(&excludedCreators, "exclude-status-creators", "", "set users whose commit statuses are not validated (comma-separated list of user logins)")
+
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputFormatText, "set output format (text or json)")
+	cmd.PersistentFlags().StringVar(&outputFile, "output-file", "", "set file path to write the validation result as JSON")
+
+	cmd.PersistentFlags().BoolVar(&stepSummary, "step-summary", true, "set whether to write the validation result to the job summary, when GITHUB_STEP_SUMMARY is available")
+	cmd.PersistentFlags().BoolVar(&annotations, "annotations", true, "set whether to annotate failed, cancelled and pending jobs when the validation fails on GitHub Actions")
+
+	cmd.PersistentFlags().StringVar(&checkRunName, "check-run-name", "", "set name of the check run to create for reporting the verdict (empty disables the check run)")
+
+	cmd.PersistentFlags().BoolVar(&prComment, "pr-comment", false, "set whether to post and edit a pull request comment with the failed, pending and ignored jobs")
+	cmd.PersistentFlags().UintVar(&prNumber, "pr-number", 0, "set number of the pull request to comment on and validate")
+
+	cmd.PersistentFlags().UintVar(&minApprovals, "min-approvals", 0, "set number of reviewers who must approve the pull request (0 disables the review validation)")
+	cmd.PersistentFlags().BoolVar(&approvalAfterPush, "require-approval-after-push", false, "set whether only approvals of the latest commit are counted")
+	cmd.PersistentFlags().BoolVar(&codeOwners, "code-owners", false, "set whether each changed file must be approved by its code owners in CODEOWNERS")
+	cmd.PersistentFlags().StringVar(&requiredLabels, "required-labels", "", "set labels which must be present on the pull request (comma-separated)")
+	cmd.PersistentFlags().StringVar(&forbiddenLabels, "forbidden-labels", "", "set labels which block the merge while present on the pull request (comma-separated)")
+	cmd.PersistentFlags().StringVar(&oneOfLabels, "one-of-labels", "", "set labels of which exactly one must be present on the pull request (comma-separated)")
+	cmd.PersistentFlags().StringVar(&titlePattern, "title-pattern", "", "set regular expression which the pull request title must match")
+	cmd.PersistentFlags().BoolVar(&conventionalTitle, "conventional-title", false, "set whether the pull request title must follow Conventional Commits")
+	cmd.PersistentFlags().StringVar(&titleTypes, "title-types", "", "set types allowed in the Conventional Commits title (comma-separated)")
+	cmd.PersistentFlags().StringVar(&titleScopes, "title-scopes", "", "set scopes allowed in the Conventional Commits title (comma-separated)")
+	cmd.PersistentFlags().BoolVar(&titleAllowBreaking, "title-allow-breaking", true, "set whether the breaking change marker is allowed in the Conventional Commits title")
+	cmd.PersistentFlags().StringVar(&bodyHeadings, "body-headings", "", "set headings which must be in the pull request body (comma-separated)")
+	cmd.PersistentFlags().StringVar(&bodyChecklist, "body-checklist", "", "set checklist items which must be ticked in the pull request body (comma-separated)")
+	cmd.PersistentFlags().BoolVar(&bodyAllChecked, "body-all-checked", false, "set whether every checklist item in the pull request body must be ticked")
+	cmd.PersistentFlags().UintVar(&commitSubjectMax, "commit-subject-max-length", 0, "set max number of characters in the subject of commit messages (0 disables the check)")
+	cmd.PersistentFlags().StringVar(&commitSubjectRegexp, "commit-subject-pattern", "", "set regular expression which the subject of commit messages must match")
+	cmd.PersistentFlags().BoolVar(&commitNoWIP, "commit-no-wip", false, "set whether WIP, fixup!, squash! and amend! commits are rejected")
+	cmd.PersistentFlags().StringVar(&commitIssueTrailers, "commit-issue-trailers", "", "set trailer keys of which one must reference an issue in commit messages (comma-separated)")
 
 	return cmd
 }
 
+// appPrivateKey returns the private key of GitHub App from the file, or the environment variable.
+func appPrivateKey() ([]byte, error) {
+	if len(appPrivateKeyFile) != 0 {
+		b, err := os.ReadFile(appPrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key of GitHub App: %w", err)
+		}
+		return b, nil
+	}
+	if str := os.Getenv(appPrivateKeyEnv); len(str) != 0 {
+		return []byte(str), nil
+	}
+	return nil, fmt.Errorf("private key of GitHub App is required, set --app-private-key-file or %s", appPrivateKeyEnv)
+}
+
 func ownerAndRepository(str string) (owner string, repo string) {
 	sp := strings.Split(str, "/")
 	switch len(sp) {
@@ -96,53 +357,135 @@ func debug(logger logger, name string) func() {
 	}
 }
 
-func doValidateCmd(ctx context.Context, logger logger, vs ...validators.Validator) error {
+// cacheStatsReporter logs hits and misses of the response cache once the validation ends.
+type cacheStatsReporter struct {
+	cache  *github.ResponseCache
+	logger logger
+}
+
+func (cr *cacheStatsReporter) Report(_ context.Context, r *report) error {
+	if !r.Final {
+		return nil
+	}
+	hits, misses := cr.cache.Stats()
+	cr.logger.PrintErrf("GitHub API response cache: %d hits, %d misses in %d checks\n", hits, misses, r.Poll)
+	return nil
+}
+
+func doValidateCmd(ctx context.Context, logger logger, rs reporters, vs ...validators.Validator) error {
+	if outputFormat == outputFormatJSON {
+		logger = &stderrLogger{logger}
+	}
+	startedAt := time.Now()
+
+	// Reports after the timeout still need a live context.
+	reportCtx := ctx
+
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecond)*time.Second)
 	defer cancel()
 
-	invalT := ticker.NewInstantTicker(time.Duration(validateInvalSecond) * time.Second)
+	invalT, err := newTicker()
+	if err != nil {
+		return err
+	}
 	defer invalT.Stop()
 
+	// results holds the latest results of the validators,
+	// so that the reason can be reported when the validation times out.
+	results := make([]*validationResult, len(vs))
+
+	var poll int
+	report := func(ctx context.Context, final bool, err error) {
+		if len(rs) == 0 {
+			return
+		}
+		rs.report(ctx, logger, newReport(results, poll, time.Since(startedAt), final, err))
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			errs := multierror.Errors{ctx.Err()}
+			for _, res := range results {
+				if res != nil && res.status != nil && !res.status.IsSuccess() {
+					errs = append(errs, errors.New(res.status.Detail()))
+				}
+			}
+			report(reportCtx, true, errs)
+			return errs
 		case <-invalT.C():
-			var successCnt int
-			for _, v := range vs {
-				ok, err := validate(ctx, v, logger)
+			poll++
+
+			var incompleteCnt int
+			for i, v := range vs {
+				st, err := validate(ctx, v, logger)
+				results[i] = &validationResult{name: v.Name(), status: st, err: err}
 				if err != nil {
+					report(reportCtx, true, err)
 					return err
 				}
-				if ok {
-					successCnt++
+				if !st.IsSuccess() {
+					incompleteCnt++
 				}
 			}
-			if successCnt != len(vs) {
+			if incompleteCnt != 0 {
+				report(ctx, false, nil)
+
 				logger.PrintErrln("")
 				logger.PrintErrln("  WARNING: Validation is yet to be completed. This is most likely due to some other jobs still running.")
-				logger.PrintErrf("           Waiting for %d seconds before retrying.\n\n", validateInvalSecond)
+				if maxInvalSecond > validateInvalSecond {
+					logger.PrintErrf("           Waiting for up to %d seconds before retrying.\n\n", maxInvalSecond)
+				} else {
+					logger.PrintErrf("           Waiting for %d seconds before retrying.\n\n", validateInvalSecond)
+				}
 				break
 			}
 
+			report(reportCtx, true, nil)
 			logger.Println("All validations were successful!")
 			return nil
 		}
 	}
 }
 
-func validate(ctx context.Context, v validators.Validator, logger logger) (bool, error) {
+// newTicker returns the ticker for the polling loop.
+// The backoff ticker is used only when the interval can change, and the fixed interval ticker is used otherwise.
+func newTicker() (ticker.InstantTicker, error) {
+	interval := time.Duration(validateInvalSecond) * time.Second
+	maxInterval := time.Duration(maxInvalSecond) * time.Second
+	if maxInterval == 0 {
+		maxInterval = interval
+	}
+	if invalMultiplier > 1 && maxInterval <= interval {
+		return nil, errors.New("invalid interval: interval-multiplier requires max-interval greater than interval")
+	}
+	if invalJitter == 0 && (invalMultiplier == 1 || maxInterval == interval) {
+		return ticker.NewInstantTicker(interval), nil
+	}
+
+	t, err := ticker.NewBackoffTicker(ticker.BackoffConfig{
+		Initial:    interval,
+		Max:        maxInterval,
+		Multiplier: invalMultiplier,
+		Jitter:     invalJitter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval: %w", err)
+	}
+	return t, nil
+}
+
+// validate runs the validator, and returns its status.
+// The status is returned even with an error, if the validator provides it.
+func validate(ctx context.Context, v validators.Validator, logger logger) (validators.Status, error) {
 	defer debug(logger, "validator: "+v.Name())()
 
 	st, err := v.Validate(ctx)
 	if err != nil {
-		return false, fmt.Errorf("validation failed, err: %v", err)
+		return st, fmt.Errorf("validation failed, err: %v", err)
 	}
 
 	logger.Println(st.Detail())
 
-	if !st.IsSuccess() {
-		return false, nil
-	}
-	return true, nil
+	return st, nil
 }
@@ -1,13 +1,16 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"testing"
 
 	"github.com/spf13/cobra"
 
+	"github.com/upsidr/merge-gatekeeper/internal/github"
 	"github.com/upsidr/merge-gatekeeper/internal/validators"
 	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
 )
@@ -15,6 +18,11 @@ import (
 func TestMain(m *testing.M) {
 	validateInvalSecond = 1
 	timeoutSecond = 2
+
+	// Tests must not write to the files of the runner when they run on GitHub Actions.
+	for _, env := range []string{githubActionsEnv, stepSummaryEnv, outputEnv} {
+		os.Unsetenv(env)
+	}
 	os.Exit(m.Run())
 }
 
@@ -138,9 +146,109 @@ func Test_doValidateCmd(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			if err := doValidateCmd(tt.ctx, tt.cmd, tt.vs...); (err != nil) != tt.wantErr {
+			if err := doValidateCmd(tt.ctx, tt.cmd, nil, tt.vs...); (err != nil) != tt.wantErr {
 				t.Errorf("doValidateCmd() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_doValidateCmd_jsonOutput(t *testing.T) {
+	outputFormat = outputFormatJSON
+	defer func() { outputFormat = outputFormatText }()
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+
+	v := &mock.Validator{
+		NameFunc: func() string { return "validator-1" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			return &mock.Status{
+				DetailFunc:    func() string { return "success-1" },
+				IsSuccessFunc: func() bool { return true },
+				JobsFunc: func() []*validators.Job {
+					return []*validators.Job{{Name: "job-01", State: validators.JobStateSuccess}}
+				},
+			}, nil
+		},
+	}
+	rs, err := newReporters(cmd)
+	if err != nil {
+		t.Fatalf("newReporters() error = %v", err)
+	}
+	if err := doValidateCmd(context.Background(), cmd, rs, v); err != nil {
+		t.Fatalf("doValidateCmd() error = %v", err)
+	}
+
+	var got report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("doValidateCmd() output is not a JSON document: %v\n%s", err, buf.String())
+	}
+	if got.Result != resultSuccess || !got.Final || got.Validators[0].Counts[validators.JobStateSuccess] != 1 {
+		t.Errorf("doValidateCmd() output = %+v, want final success report", got)
+	}
+}
+
+func Test_cacheStatsReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&buf)
+
+	cr := &cacheStatsReporter{cache: github.NewResponseCache(), logger: cmd}
+	for _, r := range []*report{{Poll: 1}, {Poll: 2}, {Poll: 3, Final: true}} {
+		if err := cr.Report(context.Background(), r); err != nil {
+			t.Fatalf("cacheStatsReporter.Report() error = %v", err)
+		}
+	}
+	if got, want := buf.String(), "GitHub API response cache: 0 hits, 0 misses in 3 checks\n"; got != want {
+		t.Errorf("cacheStatsReporter.Report() logged %q, want %q", got, want)
+	}
+}
+
+func Test_newTicker(t *testing.T) {
+	tests := map[string]struct {
+		maxInvalSecond  uint
+		invalMultiplier float64
+		invalJitter     float64
+		wantErr         bool
+	}{
+		"returns fixed interval ticker by default": {
+			invalMultiplier: 1,
+		},
+		"returns backoff ticker": {
+			maxInvalSecond:  10,
+			invalMultiplier: 2,
+			invalJitter:     0.1,
+		},
+		"returns error when backoff config is invalid": {
+			maxInvalSecond:  10,
+			invalMultiplier: 0.5,
+			wantErr:         true,
+		},
+		"returns error when multiplier is set without max interval": {
+			invalMultiplier: 2,
+			wantErr:         true,
+		},
+		"returns backoff ticker with jitter only": {
+			invalMultiplier: 1,
+			invalJitter:     0.1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func(max uint, mul, jitter float64) {
+				maxInvalSecond, invalMultiplier, invalJitter = max, mul, jitter
+			}(maxInvalSecond, invalMultiplier, invalJitter)
+			maxInvalSecond, invalMultiplier, invalJitter = tt.maxInvalSecond, tt.invalMultiplier, tt.invalJitter
+
+			got, err := newTicker()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newTicker() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != nil {
+				got.Stop()
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// GitHub accepts JWT which expires within 10 minutes, and the issued time is set to the past for clock drift.
+	// NOTE: https://docs.github.com/en/developers/apps/building-github-apps/authenticating-with-github-apps#authenticating-as-a-github-app
+	appJWTExpiry    = 9 * time.Minute
+	appJWTClockSkew = time.Minute
+
+	// installationTokenRefreshMargin is how long before the expiry the installation token is refreshed.
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// AppConfig is the configuration to authenticate as an installation of GitHub App.
+type AppConfig struct {
+	ID         int64
+	PrivateKey []byte // PEM encoded RSA private key
+
+	// InstallationID is the ID of the installation. When it is 0, the installation is found by Owner and Repo.
+	InstallationID int64
+	Owner          string
+	Repo           string
+}
+
+// WithApp sets the GitHub App to authenticate as its installation instead of the token.
+func WithApp(cfg AppConfig) Option {
+	return func(o *options) {
+		o.app = &cfg
+	}
+}
+
+func parsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA key")
+	}
+	return rsaKey, nil
+}
+
+// appJWT returns JWT signed with RS256 to authenticate as the GitHub App.
+func appJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTExpiry).Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// appTransport authenticates requests as the GitHub App with JWT.
+type appTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+	base  http.RoundTripper
+	now   func() time.Time
+}
+
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := appJWT(t.appID, t.key, t.now())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	return t.base.RoundTrip(req)
+}
+
+// installationTokenSource mints installation tokens of the GitHub App.
+// It is wrapped by oauth2.ReuseTokenSource, so that a new token is minted only when the current one is about to expire.
+type installationTokenSource struct {
+	ctx    context.Context
+	app    *github.Client // authenticated as the GitHub App
+	cfg    AppConfig
+	logger Logger
+
+	mu             sync.Mutex
+	installationID int64
+}
+
+func (ts *installationTokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.installationID == 0 {
+		inst, _, err := ts.app.Apps.FindRepositoryInstallation(ts.ctx, ts.cfg.Owner, ts.cfg.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find installation of GitHub App %d for %s/%s: %w", ts.cfg.ID, ts.cfg.Owner, ts.cfg.Repo, err)
+		}
+		ts.installationID = inst.GetID()
+	}
+
+	token, _, err := ts.app.Apps.CreateInstallationToken(ts.ctx, ts.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installation token of GitHub App %d: %w", ts.cfg.ID, err)
+	}
+	if ts.logger != nil {
+		ts.logger.PrintErrf("Minted installation token of GitHub App %d, which expires at %s\n", ts.cfg.ID, token.GetExpiresAt().Format(time.RFC3339))
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		Expiry:      token.GetExpiresAt().Add(-installationTokenRefreshMargin),
+	}, nil
+}
+
+// newAppTokenSource returns the token source of the installation of the GitHub App.
+// newClient creates the GitHub client with the given HTTP client, so that the same URLs are used for the app.
+func newAppTokenSource(ctx context.Context, cfg AppConfig, base http.RoundTripper, logger Logger, newClient func(hc *http.Client) (*github.Client, error)) (oauth2.TokenSource, error) {
+	if cfg.ID == 0 {
+		return nil, errors.New("GitHub App ID is empty")
+	}
+	if cfg.InstallationID == 0 && (len(cfg.Owner) == 0 || len(cfg.Repo) == 0) {
+		return nil, errors.New("GitHub App installation ID, or owner and repository to find the installation is required")
+	}
+	key, err := parsePrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := newClient(&http.Client{
+		Transport: &appTransport{appID: cfg.ID, key: key, base: base, now: time.Now},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return oauth2.ReuseTokenSource(nil, &installationTokenSource{
+		ctx:            ctx,
+		app:            app,
+		cfg:            cfg,
+		logger:         logger,
+		installationID: cfg.InstallationID,
+	}), nil
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func generateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// verifyJWT verifies the signature of JWT, and returns its claims.
+func verifyJWT(t *testing.T, jwt string, pub *rsa.PublicKey) map[string]int64 {
+	t.Helper()
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		t.Fatalf("JWT signature is invalid: %v", err)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]int64{}
+	if err := json.Unmarshal(b, &claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func Test_appJWT(t *testing.T) {
+	key, _ := generateKey(t)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	jwt, err := appJWT(12345, key, now)
+	if err != nil {
+		t.Fatalf("appJWT() error = %v", err)
+	}
+	claims := verifyJWT(t, jwt, &key.PublicKey)
+	want := map[string]int64{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": 12345,
+	}
+	for k, v := range want {
+		if claims[k] != v {
+			t.Errorf("appJWT() claim %s = %d, want %d", k, claims[k], v)
+		}
+	}
+}
+
+func Test_parsePrivateKey(t *testing.T) {
+	key, pkcs1 := generateKey(t)
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})
+
+	tests := map[string]struct {
+		b       []byte
+		wantErr bool
+	}{
+		"parses PKCS #1 key": {
+			b: pkcs1,
+		},
+		"parses PKCS #8 key": {
+			b: pkcs8,
+		},
+		"returns error when key is not PEM encoded": {
+			b:       []byte("not a key"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parsePrivateKey(tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(key) {
+				t.Error("parsePrivateKey() returned different key")
+			}
+		})
+	}
+}
+
+func TestNewClient_app(t *testing.T) {
+	key, pemKey := generateKey(t)
+
+	var lookups, mints int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/api/v3/repos/upsidr/merge-gatekeeper/installation":
+			lookups++
+			if claims := verifyJWT(t, strings.TrimPrefix(auth, "Bearer "), &key.PublicKey); claims["iss"] != 12345 {
+				t.Errorf("JWT iss = %d, want 12345", claims["iss"])
+			}
+			fmt.Fprint(w, `{"id":42}`)
+		case "/api/v3/app/installations/42/access_tokens":
+			mints++
+			verifyJWT(t, strings.TrimPrefix(auth, "Bearer "), &key.PublicKey)
+			// The token expires within the refresh margin, so that it is refreshed on the next request.
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`, mints, time.Now().Add(time.Minute).Format(time.RFC3339))
+		case "/api/v3/repos/upsidr/merge-gatekeeper/commits/sha/status":
+			if want := fmt.Sprintf("Bearer ghs_%d", mints); auth != want {
+				t.Errorf("Authorization = %s, want %s", auth, want)
+			}
+			fmt.Fprint(w, `{"state":"success"}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(context.Background(), "",
+		WithEnterpriseURLs(srv.URL+"/api/v3", ""),
+		WithApp(AppConfig{ID: 12345, PrivateKey: pemKey, Owner: "upsidr", Repo: "merge-gatekeeper"}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.GetCombinedStatus(context.Background(), "upsidr", "merge-gatekeeper", "sha", nil); err != nil {
+			t.Fatalf("GetCombinedStatus() error = %v", err)
+		}
+	}
+	if lookups != 1 || mints != 2 {
+		t.Errorf("installation was looked up %d times and token was minted %d times, want 1 and 2", lookups, mints)
+	}
+}
+
+func TestNewClient_appConfigError(t *testing.T) {
+	_, pemKey := generateKey(t)
+	tests := map[string]AppConfig{
+		"returns error when app id is empty":                            {PrivateKey: pemKey, InstallationID: 1},
+		"returns error when neither installation nor repository is set": {ID: 1, PrivateKey: pemKey},
+		"returns error when private key is invalid":                     {ID: 1, PrivateKey: []byte("invalid"), InstallationID: 1},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(context.Background(), "", WithApp(cfg)); err == nil {
+				t.Error("NewClient() error = nil, want error")
+			}
+		})
+	}
+}
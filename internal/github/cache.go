@@ -0,0 +1,123 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// ResponseCache caches responses of GET requests by their ETag, and sends conditional requests with If-None-Match.
+// Unchanged resources are served from the cache with 304 Not Modified, which does not count against the rate limit.
+// NOTE: https://docs.github.com/en/rest/overview/resources-in-the-rest-api#conditional-requests
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	hits    int
+	misses  int
+}
+
+type cacheEntry struct {
+	etag string
+	dump []byte // response dumped with httputil.DumpResponse
+}
+
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// Stats returns the number of requests served from the cache, and not.
+func (c *ResponseCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+func cacheKey(req *http.Request) string {
+	return req.Header.Get("Accept") + " " + req.URL.String()
+}
+
+func (c *ResponseCache) get(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key]
+}
+
+func (c *ResponseCache) put(key string, e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = e
+}
+
+func (c *ResponseCache) count(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+// Transport returns http.RoundTripper which serves responses from the cache when they are not modified.
+func (c *ResponseCache) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cacheTransport{cache: c, base: base}
+}
+
+type cacheTransport struct {
+	cache *ResponseCache
+	base  http.RoundTripper
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry := t.cache.get(key)
+	if entry != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		cached, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(entry.dump)), req)
+		if err != nil {
+			return resp, nil // Let the caller handle 304 as is
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		// Headers such as the rate limit are up to date in 304 response.
+		for k, v := range resp.Header {
+			cached.Header[k] = v
+		}
+		t.cache.count(true)
+		return cached, nil
+	}
+	t.cache.count(false)
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || len(etag) == 0 {
+		return resp, nil
+	}
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.put(key, &cacheEntry{etag: etag, dump: dump})
+	return resp, nil
+}
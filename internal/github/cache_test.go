@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v38/github"
+)
+
+func TestResponseCache(t *testing.T) {
+	etag := `"v1"`
+	var requests, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		if r.Method == http.MethodGet && r.Header.Get("If-None-Match") == etag {
+			notModified++
+			// 304 response does not count against the rate limit.
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"state":"success","total_count":%d}`, requests)
+	}))
+	defer srv.Close()
+
+	cache := NewResponseCache()
+	ghc := github.NewClient(&http.Client{Transport: cache.Transport(srv.Client().Transport)})
+	u, _ := url.Parse(srv.URL + "/")
+	ghc.BaseURL = u
+	c := &client{ghc: ghc}
+
+	get := func() (*CombinedStatus, *Response) {
+		t.Helper()
+		st, resp, err := c.GetCombinedStatus(context.Background(), "upsidr", "merge-gatekeeper", "sha", nil)
+		if err != nil {
+			t.Fatalf("GetCombinedStatus() error = %v", err)
+		}
+		return st, resp
+	}
+
+	// The first request is not cached.
+	st, _ := get()
+	if st.GetTotalCount() != 1 {
+		t.Errorf("GetCombinedStatus() total = %d, want 1", st.GetTotalCount())
+	}
+
+	// The second request is served from the cache, with the rate limit of 304 response.
+	st, resp := get()
+	if st.GetTotalCount() != 1 {
+		t.Errorf("GetCombinedStatus() total = %d, want cached 1", st.GetTotalCount())
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GetCombinedStatus() status code = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if resp.Rate.Remaining != 4999 {
+		t.Errorf("GetCombinedStatus() rate remaining = %d, want 4999", resp.Rate.Remaining)
+	}
+
+	// The changed resource is fetched again.
+	etag = `"v2"`
+	st, _ = get()
+	if st.GetTotalCount() != 3 {
+		t.Errorf("GetCombinedStatus() total = %d, want 3", st.GetTotalCount())
+	}
+
+	// Non-GET requests are never cached.
+	if _, _, err := c.CreateCheckRun(context.Background(), "upsidr", "merge-gatekeeper", CreateCheckRunOptions{Name: "test", HeadSHA: "sha"}); err != nil {
+		t.Fatalf("CreateCheckRun() error = %v", err)
+	}
+
+	hits, misses := cache.Stats()
+	if hits != 1 || misses != 2 {
+		t.Errorf("ResponseCache.Stats() = (%d, %d), want (1, 2)", hits, misses)
+	}
+	if requests != 4 || notModified != 1 {
+		t.Errorf("server got %d requests with %d not modified, want 4 with 1", requests, notModified)
+	}
+}
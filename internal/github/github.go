@@ -2,6 +2,13 @@ package github
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
 
 	"github.com/google/go-github/v38/github"
 	"golang.org/x/oauth2"
@@ -12,31 +19,198 @@ type (
 	CombinedStatus = github.CombinedStatus
 	RepoStatus     = github.RepoStatus
 	Response       = github.Response
+	Timestamp      = github.Timestamp
+	User           = github.User
 )
 
 type (
 	CheckRun             = github.CheckRun
 	ListCheckRunsOptions = github.ListCheckRunsOptions
 	ListCheckRunsResults = github.ListCheckRunsResults
+	App                  = github.App
+
+	CreateCheckRunOptions = github.CreateCheckRunOptions
+	UpdateCheckRunOptions = github.UpdateCheckRunOptions
+	CheckRunOutput        = github.CheckRunOutput
+)
+
+type (
+	PullRequest       = github.PullRequest
+	PullRequestReview = github.PullRequestReview
+	PullRequestBranch = github.PullRequestBranch
+	Label             = github.Label
+	CommitFile        = github.CommitFile
+	RepositoryCommit  = github.RepositoryCommit
+	Commit            = github.Commit
+)
+
+type (
+	RepositoryContent           = github.RepositoryContent
+	RepositoryContentGetOptions = github.RepositoryContentGetOptions
+	TeamListTeamMembersOptions  = github.TeamListTeamMembersOptions
+)
+
+type (
+	IssueComment             = github.IssueComment
+	IssueListCommentsOptions = github.IssueListCommentsOptions
 )
 
 type Client interface {
 	GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error)
 	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error)
+	CreateCheckRun(ctx context.Context, owner, repo string, opts CreateCheckRunOptions) (*CheckRun, *Response, error)
+	UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts UpdateCheckRunOptions) (*CheckRun, *Response, error)
+	GetCommitSHA(ctx context.Context, owner, repo, ref string) (string, *Response, error)
+	ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error)
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error)
+	EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *IssueComment) (*IssueComment, *Response, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error)
+	ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error)
+	ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error)
+	ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*RepositoryCommit, *Response, error)
+	GetContents(ctx context.Context, owner, repo, path string, opts *RepositoryContentGetOptions) (*RepositoryContent, *Response, error)
+	ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error)
 }
 
 type client struct {
 	ghc *github.Client
 }
 
-func NewClient(ctx context.Context, token string) Client {
-	return &client{
-		ghc: github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-			&oauth2.Token{
-				AccessToken: token,
-			},
-		))),
+const defaultAPIURL = "https://api.github.com"
+
+type options struct {
+	cache     *ResponseCache
+	apiURL    string
+	uploadURL string
+	caBundle  string
+	proxyURL  string
+	app       *AppConfig
+	logger    Logger
+}
+
+type Option func(o *options)
+
+// WithResponseCache sets the cache to send conditional requests for unchanged resources.
+func WithResponseCache(cache *ResponseCache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithEnterpriseURLs sets the URLs of GitHub Enterprise Server, such as https://github.example.com/api/v3.
+// The upload URL is derived from the API URL when it is empty.
+func WithEnterpriseURLs(apiURL, uploadURL string) Option {
+	return func(o *options) {
+		o.apiURL = apiURL
+		o.uploadURL = uploadURL
+	}
+}
+
+// WithCABundle sets the path of the PEM file with the CA certificates to trust in addition to the system ones.
+func WithCABundle(path string) Option {
+	return func(o *options) {
+		o.caBundle = path
+	}
+}
+
+// WithProxy sets the URL of the proxy to send requests through, instead of the proxy from the environment variables.
+func WithProxy(proxyURL string) Option {
+	return func(o *options) {
+		o.proxyURL = proxyURL
+	}
+}
+
+// WithLogger sets the logger to log what the client is doing, such as minting tokens.
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+func NewClient(ctx context.Context, token string, opts ...Option) (Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	transport, err := o.transport()
+	if err != nil {
+		return nil, err
+	}
+	if o.cache != nil {
+		transport = o.cache.Transport(transport)
+	}
+
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{
+			AccessToken: token,
+		},
+	)
+	if o.app != nil {
+		// Requests to mint tokens do not go through the cache, because they are never the same.
+		base, err := o.transport()
+		if err != nil {
+			return nil, err
+		}
+		ts, err = newAppTokenSource(ctx, *o.app, base, o.logger, o.newGitHubClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// oauth2 uses the HTTP client in the context as the base, so that the transport sees authorized requests.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+	ghc, err := o.newGitHubClient(oauth2.NewClient(ctx, ts))
+	if err != nil {
+		return nil, err
+	}
+	return &client{ghc: ghc}, nil
+}
+
+// newGitHubClient returns the client for github.com, or GitHub Enterprise Server when its URL is set.
+func (o *options) newGitHubClient(hc *http.Client) (*github.Client, error) {
+	apiURL := strings.TrimSuffix(o.apiURL, "/")
+	if len(apiURL) == 0 || apiURL == defaultAPIURL {
+		return github.NewClient(hc), nil
+	}
+
+	uploadURL := o.uploadURL
+	if len(uploadURL) == 0 {
+		uploadURL = strings.TrimSuffix(apiURL, "/api/v3")
+	}
+	ghc, err := github.NewEnterpriseClient(apiURL, uploadURL, hc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub Enterprise Server URL: %w", err)
+	}
+	return ghc, nil
+}
+
+func (o *options) transport() (http.RoundTripper, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if len(o.proxyURL) != 0 {
+		u, err := url.Parse(o.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	if len(o.caBundle) != 0 {
+		pem, err := os.ReadFile(o.caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in CA bundle %s", o.caBundle)
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
 	}
+	return t, nil
 }
 
 func (c *client) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error) {
@@ -46,3 +220,54 @@ func (c *client) GetCombinedStatus(ctx context.Context, owner, repo, ref string,
 func (c *client) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error) {
 	return c.ghc.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
 }
+
+func (c *client) CreateCheckRun(ctx context.Context, owner, repo string, opts CreateCheckRunOptions) (*CheckRun, *Response, error) {
+	return c.ghc.Checks.CreateCheckRun(ctx, owner, repo, opts)
+}
+
+func (c *client) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts UpdateCheckRunOptions) (*CheckRun, *Response, error) {
+	return c.ghc.Checks.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
+}
+
+// GetCommitSHA resolves the ref, such as a branch name or tag name, to the SHA of the commit.
+func (c *client) GetCommitSHA(ctx context.Context, owner, repo, ref string) (string, *Response, error) {
+	return c.ghc.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+}
+
+func (c *client) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error) {
+	return c.ghc.Issues.ListComments(ctx, owner, repo, number, opts)
+}
+
+func (c *client) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error) {
+	return c.ghc.Issues.CreateComment(ctx, owner, repo, number, comment)
+}
+
+func (c *client) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *IssueComment) (*IssueComment, *Response, error) {
+	return c.ghc.Issues.EditComment(ctx, owner, repo, commentID, comment)
+}
+
+func (c *client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	return c.ghc.PullRequests.Get(ctx, owner, repo, number)
+}
+
+func (c *client) ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error) {
+	return c.ghc.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+}
+
+func (c *client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error) {
+	return c.ghc.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+}
+
+func (c *client) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*RepositoryCommit, *Response, error) {
+	return c.ghc.PullRequests.ListCommits(ctx, owner, repo, number, opts)
+}
+
+// GetContents returns the content of the file. Directories are not supported.
+func (c *client) GetContents(ctx context.Context, owner, repo, path string, opts *RepositoryContentGetOptions) (*RepositoryContent, *Response, error) {
+	file, _, resp, err := c.ghc.Repositories.GetContents(ctx, owner, repo, path, opts)
+	return file, resp, err
+}
+
+func (c *client) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error) {
+	return c.ghc.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+}
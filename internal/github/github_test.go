@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := map[string]struct {
+		opts          []Option
+		wantBaseURL   string
+		wantUploadURL string
+		wantErr       bool
+	}{
+		"returns client for github.com by default": {
+			wantBaseURL:   "https://api.github.com/",
+			wantUploadURL: "https://uploads.github.com/",
+		},
+		"returns client for github.com when api url is the default": {
+			opts:          []Option{WithEnterpriseURLs("https://api.github.com/", "")},
+			wantBaseURL:   "https://api.github.com/",
+			wantUploadURL: "https://uploads.github.com/",
+		},
+		"returns client for GitHub Enterprise Server": {
+			opts:          []Option{WithEnterpriseURLs("https://github.example.com/api/v3", "")},
+			wantBaseURL:   "https://github.example.com/api/v3/",
+			wantUploadURL: "https://github.example.com/api/uploads/",
+		},
+		"returns client for GitHub Enterprise Server with upload url": {
+			opts:          []Option{WithEnterpriseURLs("https://github.example.com/api/v3", "https://uploads.example.com/api/uploads/")},
+			wantBaseURL:   "https://github.example.com/api/v3/",
+			wantUploadURL: "https://uploads.example.com/api/uploads/",
+		},
+		"returns error when CA bundle does not exist": {
+			opts:    []Option{WithCABundle(filepath.Join(t.TempDir(), "not-found.pem"))},
+			wantErr: true,
+		},
+		"returns error when proxy url is invalid": {
+			opts:    []Option{WithProxy("://proxy")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewClient(context.Background(), "token", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			c := got.(*client)
+			if c.ghc.BaseURL.String() != tt.wantBaseURL {
+				t.Errorf("NewClient() base url = %s, want %s", c.ghc.BaseURL, tt.wantBaseURL)
+			}
+			if c.ghc.UploadURL.String() != tt.wantUploadURL {
+				t.Errorf("NewClient() upload url = %s, want %s", c.ghc.UploadURL, tt.wantUploadURL)
+			}
+		})
+	}
+}
+
+func TestNewClient_caBundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"state":"success"}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(context.Background(), "token", WithEnterpriseURLs(srv.URL+"/api/v3", ""), WithCABundle(path))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	st, _, err := c.GetCombinedStatus(context.Background(), "upsidr", "merge-gatekeeper", "sha", nil)
+	if err != nil {
+		t.Fatalf("GetCombinedStatus() error = %v", err)
+	}
+	if st.GetState() != "success" {
+		t.Errorf("GetCombinedStatus() state = %s, want success", st.GetState())
+	}
+}
+
+func TestNewClient_proxy(t *testing.T) {
+	var gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		fmt.Fprint(w, `{"state":"success"}`)
+	}))
+	defer proxy.Close()
+
+	c, err := NewClient(context.Background(), "token", WithEnterpriseURLs("http://github.example.com/api/v3", ""), WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, _, err := c.GetCombinedStatus(context.Background(), "upsidr", "merge-gatekeeper", "sha", nil); err != nil {
+		t.Fatalf("GetCombinedStatus() error = %v", err)
+	}
+	if want := "http://github.example.com/api/v3/repos/upsidr/merge-gatekeeper/commits/sha/status"; gotURL != want {
+		t.Errorf("proxy got request to %s, want %s", gotURL, want)
+	}
+}
@@ -7,8 +7,20 @@ import (
 )
 
 type Client struct {
-	GetCombinedStatusFunc   func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error)
-	ListCheckRunsForRefFunc func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	GetCombinedStatusFunc      func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error)
+	ListCheckRunsForRefFunc    func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	CreateCheckRunFunc         func(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	UpdateCheckRunFunc         func(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	GetCommitSHAFunc           func(ctx context.Context, owner, repo, ref string) (string, *github.Response, error)
+	ListIssueCommentsFunc      func(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+	CreateIssueCommentFunc     func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	EditIssueCommentFunc       func(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	GetPullRequestFunc         func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListReviewsFunc            func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error)
+	ListPullRequestFilesFunc   func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error)
+	ListPullRequestCommitsFunc func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	GetContentsFunc            func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error)
+	ListTeamMembersBySlugFunc  func(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error)
 }
 
 func (c *Client) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
@@ -19,6 +31,103 @@ func (c *Client) ListCheckRunsForRef(ctx context.Context, owner, repo, ref strin
 	return c.ListCheckRunsForRefFunc(ctx, owner, repo, ref, opts)
 }
 
+func (c *Client) CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return c.CreateCheckRunFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return c.UpdateCheckRunFunc(ctx, owner, repo, checkRunID, opts)
+}
+
+func (c *Client) GetCommitSHA(ctx context.Context, owner, repo, ref string) (string, *github.Response, error) {
+	return c.GetCommitSHAFunc(ctx, owner, repo, ref)
+}
+
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return c.ListIssueCommentsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return c.CreateIssueCommentFunc(ctx, owner, repo, number, comment)
+}
+
+func (c *Client) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return c.EditIssueCommentFunc(ctx, owner, repo, commentID, comment)
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return c.GetPullRequestFunc(ctx, owner, repo, number)
+}
+
+func (c *Client) ListReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+	return c.ListReviewsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	return c.ListPullRequestFilesFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	return c.ListPullRequestCommitsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error) {
+	return c.GetContentsFunc(ctx, owner, repo, path, opts)
+}
+
+func (c *Client) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+	return c.ListTeamMembersBySlugFunc(ctx, org, slug, opts)
+}
+
 var (
 	_ github.Client = &Client{}
 )
+
+// CombinedStatusPages returns GetCombinedStatusFunc which serves the statuses page by page.
+// Like the GitHub API, Response.NextPage is set when there is a next page, and TotalCount is the total across all pages.
+func CombinedStatusPages(statuses []*github.RepoStatus) func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	return func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+		start, end, next := page(len(statuses), opts)
+		total := len(statuses)
+		return &github.CombinedStatus{
+			Statuses:   statuses[start:end],
+			TotalCount: &total,
+		}, &github.Response{NextPage: next}, nil
+	}
+}
+
+// CheckRunPages returns ListCheckRunsForRefFunc which serves the check runs page by page.
+// Like the GitHub API, Response.NextPage is set when there is a next page, and Total is the total across all pages.
+func CheckRunPages(checkRuns []*github.CheckRun) func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	return func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+		start, end, next := page(len(checkRuns), &opts.ListOptions)
+		total := len(checkRuns)
+		return &github.ListCheckRunsResults{
+			CheckRuns: checkRuns[start:end],
+			Total:     &total,
+		}, &github.Response{NextPage: next}, nil
+	}
+}
+
+func page(total int, opts *github.ListOptions) (start, end, next int) {
+	p, perPage := opts.Page, opts.PerPage
+	if p < 1 {
+		p = 1
+	}
+	if perPage < 1 {
+		perPage = 30 // Same as the default of the GitHub API
+	}
+
+	start = (p - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end = start + perPage
+	if end > total {
+		end = total
+	}
+	if end < total {
+		next = p + 1
+	}
+	return start, end, next
+}
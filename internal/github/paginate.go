@@ -0,0 +1,31 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxPages is the hard limit of pages to fetch, which prevents endless pagination.
+const MaxPages = 50
+
+var ErrTooManyPages = errors.New("github paginated response has too many pages")
+
+// Paginate calls fetch with the page numbers from 1 until the response has no next page.
+// It fails with ErrTooManyPages when there are more than maxPages pages of the items described by what.
+func Paginate(maxPages int, what string, fetch func(page int) (*Response, error)) error {
+	page := 1
+	for i := 1; ; i++ {
+		resp, err := fetch(page)
+		if err != nil {
+			return err
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return nil
+		}
+		if i >= maxPages {
+			return fmt.Errorf("%w: more than %d pages of %s", ErrTooManyPages, maxPages, what)
+		}
+		page = resp.NextPage
+	}
+}
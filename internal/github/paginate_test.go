@@ -0,0 +1,60 @@
+package github
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	errFetch := errors.New("err")
+	tests := map[string]struct {
+		maxPages  int
+		next      func(page int) (*Response, error)
+		wantPages []int
+		wantErrIs error
+	}{
+		"fetches pages until there is no next page": {
+			maxPages: 3,
+			next: func(page int) (*Response, error) {
+				if page == 3 {
+					return &Response{}, nil
+				}
+				return &Response{NextPage: page + 1}, nil
+			},
+			wantPages: []int{1, 2, 3},
+		},
+		"stops when the response is nil": {
+			maxPages:  3,
+			next:      func(page int) (*Response, error) { return nil, nil },
+			wantPages: []int{1},
+		},
+		"returns error of fetch": {
+			maxPages:  3,
+			next:      func(page int) (*Response, error) { return nil, errFetch },
+			wantPages: []int{1},
+			wantErrIs: errFetch,
+		},
+		"returns error when the pages never end": {
+			maxPages:  2,
+			next:      func(page int) (*Response, error) { return &Response{NextPage: page + 1}, nil },
+			wantPages: []int{1, 2},
+			wantErrIs: ErrTooManyPages,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var pages []int
+			err := Paginate(tt.maxPages, "items", func(page int) (*Response, error) {
+				pages = append(pages, page)
+				return tt.next(page)
+			})
+			if !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Paginate() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if !reflect.DeepEqual(pages, tt.wantPages) {
+				t.Errorf("Paginate() fetched pages %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+)
+
+const (
+	defaultMaxRetries = 5
+
+	// minimum wait for secondary rate limits without Retry-After, which GitHub recommends.
+	// NOTE: https://docs.github.com/en/rest/overview/resources-in-the-rest-api#secondary-rate-limits
+	secondaryRateLimitWait = time.Minute
+
+	serverErrorBaseWait = time.Second
+	serverErrorMaxWait  = 30 * time.Second
+
+	// rateLimitReserve is the remaining quota below which calls wait for the rate limit reset,
+	// so that polling slows down before the calls start failing.
+	rateLimitReserve = 10
+)
+
+// Logger is used to log what the client is doing, such as waiting for the rate limit.
+type Logger interface {
+	PrintErrf(format string, i ...interface{})
+}
+
+// retryClient wraps Client to wait for rate limits and retry server errors,
+// so that a transient error during polling does not fail the whole validation.
+type retryClient struct {
+	Client
+	logger     Logger
+	maxRetries int
+	now        func() time.Time
+	sleep      func(ctx context.Context, d time.Duration) error
+
+	mu   sync.Mutex
+	rate github.Rate // rate limit of the last successful response
+}
+
+func NewRetryClient(c Client, logger Logger) Client {
+	return &retryClient{
+		Client:     c,
+		logger:     logger,
+		maxRetries: defaultMaxRetries,
+		now:        time.Now,
+		sleep:      sleep,
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// do calls the API, and retries it while the error is retryable.
+// Server errors are retried only when the call is idempotent, because the request may have been processed.
+func (rc *retryClient) do(ctx context.Context, name string, idempotent bool, call func() (*Response, error)) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := rc.throttle(ctx, name); err != nil {
+			return nil, err
+		}
+		resp, err := call()
+		if err == nil && resp != nil {
+			rc.mu.Lock()
+			rc.rate = resp.Rate
+			rc.mu.Unlock()
+		}
+		if err == nil || attempt >= rc.maxRetries {
+			return resp, err
+		}
+
+		wait, reason, ok := rc.retryWait(resp, err, attempt, idempotent)
+		if !ok {
+			return resp, err
+		}
+		rc.logger.PrintErrf("  WARNING: %s: %s, retrying in %s (attempt %d of %d)\n", name, reason, wait.Round(time.Second), attempt+1, rc.maxRetries)
+		if serr := rc.sleep(ctx, wait); serr != nil {
+			return resp, err
+		}
+	}
+}
+
+// throttle waits until the rate limit is reset when the remaining quota of the last response is below rateLimitReserve.
+func (rc *retryClient) throttle(ctx context.Context, name string) error {
+	rc.mu.Lock()
+	rate := rc.rate
+	rc.mu.Unlock()
+	if rate.Limit == 0 || rate.Remaining >= rateLimitReserve || !rate.Reset.Time.After(rc.now()) {
+		return nil
+	}
+
+	wait := rate.Reset.Time.Sub(rc.now()) + time.Second
+	rc.logger.PrintErrf("  WARNING: %s: %d of %d API calls remaining, waiting %s for the rate limit reset\n", name, rate.Remaining, rate.Limit, wait.Round(time.Second))
+	if err := rc.sleep(ctx, wait); err != nil {
+		return err
+	}
+	// The quota is refilled, and thus the next call is not throttled until its response tells otherwise.
+	rc.mu.Lock()
+	rc.rate = github.Rate{}
+	rc.mu.Unlock()
+	return nil
+}
+
+// retryWait returns how long to wait before retrying the failed call, and false when it should not be retried.
+func (rc *retryClient) retryWait(resp *Response, err error, attempt int, idempotent bool) (time.Duration, string, bool) {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		wait := rateLimitErr.Rate.Reset.Time.Sub(rc.now()) + time.Second
+		if wait < time.Second {
+			wait = time.Second
+		}
+		return wait, "rate limit exceeded", true
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if retryAfter := abuseErr.GetRetryAfter(); retryAfter > 0 {
+			return retryAfter, "secondary rate limit exceeded", true
+		}
+		return secondaryRateLimitWait, "secondary rate limit exceeded", true
+	}
+
+	if resp == nil || resp.Response == nil {
+		return 0, "", false
+	}
+
+	// Secondary rate limits are not always detected by go-github, but they come with Retry-After.
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter, "secondary rate limit exceeded", true
+		}
+		return 0, "", false
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError && idempotent {
+		wait := serverErrorBaseWait << attempt
+		if wait > serverErrorMaxWait || wait <= 0 {
+			wait = serverErrorMaxWait
+		}
+		return wait, "server error " + strconv.Itoa(resp.StatusCode), true
+	}
+	return 0, "", false
+}
+
+func parseRetryAfter(str string) (time.Duration, bool) {
+	sec, err := strconv.Atoi(str)
+	if err != nil || sec < 0 {
+		return 0, false
+	}
+	return time.Duration(sec) * time.Second, true
+}
+
+func (rc *retryClient) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error) {
+	var st *CombinedStatus
+	resp, err := rc.do(ctx, "get combined status", true, func() (resp *Response, err error) {
+		st, resp, err = rc.Client.GetCombinedStatus(ctx, owner, repo, ref, opts)
+		return resp, err
+	})
+	return st, resp, err
+}
+
+func (rc *retryClient) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error) {
+	var res *ListCheckRunsResults
+	resp, err := rc.do(ctx, "list check runs", true, func() (resp *Response, err error) {
+		res, resp, err = rc.Client.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+		return resp, err
+	})
+	return res, resp, err
+}
+
+func (rc *retryClient) CreateCheckRun(ctx context.Context, owner, repo string, opts CreateCheckRunOptions) (*CheckRun, *Response, error) {
+	var run *CheckRun
+	resp, err := rc.do(ctx, "create check run", false, func() (resp *Response, err error) {
+		run, resp, err = rc.Client.CreateCheckRun(ctx, owner, repo, opts)
+		return resp, err
+	})
+	return run, resp, err
+}
+
+func (rc *retryClient) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts UpdateCheckRunOptions) (*CheckRun, *Response, error) {
+	var run *CheckRun
+	resp, err := rc.do(ctx, "update check run", true, func() (resp *Response, err error) {
+		run, resp, err = rc.Client.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
+		return resp, err
+	})
+	return run, resp, err
+}
+
+func (rc *retryClient) GetCommitSHA(ctx context.Context, owner, repo, ref string) (string, *Response, error) {
+	var sha string
+	resp, err := rc.do(ctx, "get commit SHA", true, func() (resp *Response, err error) {
+		sha, resp, err = rc.Client.GetCommitSHA(ctx, owner, repo, ref)
+		return resp, err
+	})
+	return sha, resp, err
+}
+
+func (rc *retryClient) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error) {
+	var comments []*IssueComment
+	resp, err := rc.do(ctx, "list issue comments", true, func() (resp *Response, err error) {
+		comments, resp, err = rc.Client.ListIssueComments(ctx, owner, repo, number, opts)
+		return resp, err
+	})
+	return comments, resp, err
+}
+
+func (rc *retryClient) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error) {
+	var created *IssueComment
+	resp, err := rc.do(ctx, "create issue comment", false, func() (resp *Response, err error) {
+		created, resp, err = rc.Client.CreateIssueComment(ctx, owner, repo, number, comment)
+		return resp, err
+	})
+	return created, resp, err
+}
+
+func (rc *retryClient) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *IssueComment) (*IssueComment, *Response, error) {
+	var edited *IssueComment
+	resp, err := rc.do(ctx, "edit issue comment", true, func() (resp *Response, err error) {
+		edited, resp, err = rc.Client.EditIssueComment(ctx, owner, repo, commentID, comment)
+		return resp, err
+	})
+	return edited, resp, err
+}
+
+func (rc *retryClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	var pr *PullRequest
+	resp, err := rc.do(ctx, "get pull request", true, func() (resp *Response, err error) {
+		pr, resp, err = rc.Client.GetPullRequest(ctx, owner, repo, number)
+		return resp, err
+	})
+	return pr, resp, err
+}
+
+func (rc *retryClient) ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error) {
+	var reviews []*PullRequestReview
+	resp, err := rc.do(ctx, "list reviews", true, func() (resp *Response, err error) {
+		reviews, resp, err = rc.Client.ListReviews(ctx, owner, repo, number, opts)
+		return resp, err
+	})
+	return reviews, resp, err
+}
+
+func (rc *retryClient) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error) {
+	var files []*CommitFile
+	resp, err := rc.do(ctx, "list pull request files", true, func() (resp *Response, err error) {
+		files, resp, err = rc.Client.ListPullRequestFiles(ctx, owner, repo, number, opts)
+		return resp, err
+	})
+	return files, resp, err
+}
+
+func (rc *retryClient) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*RepositoryCommit, *Response, error) {
+	var commits []*RepositoryCommit
+	resp, err := rc.do(ctx, "list pull request commits", true, func() (resp *Response, err error) {
+		commits, resp, err = rc.Client.ListPullRequestCommits(ctx, owner, repo, number, opts)
+		return resp, err
+	})
+	return commits, resp, err
+}
+
+func (rc *retryClient) GetContents(ctx context.Context, owner, repo, path string, opts *RepositoryContentGetOptions) (*RepositoryContent, *Response, error) {
+	var content *RepositoryContent
+	resp, err := rc.do(ctx, "get contents", true, func() (resp *Response, err error) {
+		content, resp, err = rc.Client.GetContents(ctx, owner, repo, path, opts)
+		return resp, err
+	})
+	return content, resp, err
+}
+
+func (rc *retryClient) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error) {
+	var users []*User
+	resp, err := rc.do(ctx, "list team members", true, func() (resp *Response, err error) {
+		users, resp, err = rc.Client.ListTeamMembersBySlug(ctx, org, slug, opts)
+		return resp, err
+	})
+	return users, resp, err
+}
@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) PrintErrf(format string, i ...interface{}) {}
+
+// newTestClient returns the client which sends requests to the test server.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ghc := github.NewClient(srv.Client())
+	u, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ghc.BaseURL = u
+	return &client{ghc: ghc}
+}
+
+// response writes the response of the test server.
+type response struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+func Test_retryClient(t *testing.T) {
+	reset := time.Now().Add(-time.Second).Truncate(time.Second)
+	now := reset.Add(-10 * time.Second)
+
+	tests := map[string]struct {
+		responses    []response
+		create       bool // calls non-idempotent API instead
+		maxRetries   int
+		wantErr      bool
+		wantRequests int
+		wantSleeps   []time.Duration
+	}{
+		"retries server errors with backoff": {
+			responses: []response{
+				{status: http.StatusBadGateway},
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusOK, body: `{"state":"success"}`},
+			},
+			wantRequests: 3,
+			wantSleeps:   []time.Duration{time.Second, 2 * time.Second},
+		},
+		"gives up server errors after max retries": {
+			responses: []response{
+				{status: http.StatusBadGateway},
+				{status: http.StatusBadGateway},
+				{status: http.StatusBadGateway},
+			},
+			maxRetries:   2,
+			wantErr:      true,
+			wantRequests: 3,
+			wantSleeps:   []time.Duration{time.Second, 2 * time.Second},
+		},
+		"waits until reset when rate limit is exhausted": {
+			responses: []response{
+				{
+					status: http.StatusForbidden,
+					header: map[string]string{
+						"X-RateLimit-Limit":     "5000",
+						"X-RateLimit-Remaining": "0",
+						"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+					},
+					body: `{"message":"API rate limit exceeded"}`,
+				},
+				{status: http.StatusOK, body: `{"state":"success"}`},
+			},
+			wantRequests: 2,
+			wantSleeps:   []time.Duration{11 * time.Second},
+		},
+		"honours Retry-After of secondary rate limit": {
+			responses: []response{
+				{
+					status: http.StatusForbidden,
+					header: map[string]string{"Retry-After": "30"},
+					body:   `{"message":"You have exceeded a secondary rate limit","documentation_url":"https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`,
+				},
+				{status: http.StatusOK, body: `{"state":"success"}`},
+			},
+			wantRequests: 2,
+			wantSleeps:   []time.Duration{30 * time.Second},
+		},
+		"honours Retry-After of abuse rate limit": {
+			responses: []response{
+				{
+					status: http.StatusForbidden,
+					header: map[string]string{"Retry-After": "10"},
+					body:   `{"message":"abuse","documentation_url":"https://docs.github.com/en/free-pro-team@latest/rest/reference/#abuse-rate-limits"}`,
+				},
+				{status: http.StatusOK, body: `{"state":"success"}`},
+			},
+			wantRequests: 2,
+			wantSleeps:   []time.Duration{10 * time.Second},
+		},
+		"does not retry client errors": {
+			responses: []response{
+				{status: http.StatusNotFound, body: `{"message":"Not Found"}`},
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		"does not retry server errors of non-idempotent API": {
+			responses: []response{
+				{status: http.StatusBadGateway},
+			},
+			create:       true,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				res := tt.responses[requests]
+				requests++
+				for k, v := range res.header {
+					w.Header().Set(k, v)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(res.status)
+				fmt.Fprint(w, res.body)
+			})
+
+			var sleeps []time.Duration
+			rc := &retryClient{
+				Client:     c,
+				logger:     nopLogger{},
+				maxRetries: defaultMaxRetries,
+				now:        func() time.Time { return now },
+				sleep: func(ctx context.Context, d time.Duration) error {
+					sleeps = append(sleeps, d)
+					return nil
+				},
+			}
+			if tt.maxRetries != 0 {
+				rc.maxRetries = tt.maxRetries
+			}
+
+			var err error
+			if tt.create {
+				_, _, err = rc.CreateCheckRun(context.Background(), "upsidr", "merge-gatekeeper", CreateCheckRunOptions{Name: "test", HeadSHA: "sha"})
+			} else {
+				_, _, err = rc.GetCombinedStatus(context.Background(), "upsidr", "merge-gatekeeper", "sha", nil)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retryClient error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("retryClient sent %d requests, want %d", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(sleeps, tt.wantSleeps) {
+				t.Errorf("retryClient slept %v, want %v", sleeps, tt.wantSleeps)
+			}
+		})
+	}
+}
+
+func Test_retryClient_throttle(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	reset := now.Add(30 * time.Second)
+
+	tests := map[string]struct {
+		remaining    string
+		reset        time.Time
+		wantSleeps   []time.Duration
+		wantRequests int
+	}{
+		"waits until reset before the next call when the quota is running out": {
+			remaining:    "5",
+			reset:        reset,
+			wantSleeps:   []time.Duration{31 * time.Second},
+			wantRequests: 3,
+		},
+		"does not wait when the quota is enough": {
+			remaining:    "4000",
+			reset:        reset,
+			wantRequests: 3,
+		},
+		"does not wait when the rate limit is already reset": {
+			remaining:    "5",
+			reset:        now.Add(-time.Second),
+			wantRequests: 3,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				remaining := tt.remaining
+				if requests > 1 {
+					// The quota is refilled after the reset.
+					remaining = "5000"
+				}
+				w.Header().Set("X-RateLimit-Limit", "5000")
+				w.Header().Set("X-RateLimit-Remaining", remaining)
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(tt.reset.Unix(), 10))
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"state":"success"}`)
+			})
+
+			var sleeps []time.Duration
+			rc := &retryClient{
+				Client:     c,
+				logger:     nopLogger{},
+				maxRetries: defaultMaxRetries,
+				now:        func() time.Time { return now },
+				sleep: func(ctx context.Context, d time.Duration) error {
+					sleeps = append(sleeps, d)
+					return nil
+				},
+			}
+			for i := 0; i < 3; i++ {
+				if _, _, err := rc.GetCombinedStatus(context.Background(), "upsidr", "merge-gatekeeper", "sha", nil); err != nil {
+					t.Fatalf("retryClient error = %v", err)
+				}
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("retryClient sent %d requests, want %d", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(sleeps, tt.wantSleeps) {
+				t.Errorf("retryClient slept %v, want %v", sleeps, tt.wantSleeps)
+			}
+		})
+	}
+}
+
+func Test_retryClient_throttle_cancel(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("retryClient sent a request while the quota is running out")
+	})
+	rc := NewRetryClient(c, nopLogger{}).(*retryClient)
+	rc.rate = github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: time.Now().Add(time.Hour)}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := rc.GetCombinedStatus(ctx, "upsidr", "merge-gatekeeper", "sha", nil); err == nil {
+		t.Error("retryClient error = nil, want error when context is canceled")
+	}
+}
+
+func Test_retryClient_cancel(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	rc := NewRetryClient(c, nopLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := rc.GetCombinedStatus(ctx, "upsidr", "merge-gatekeeper", "sha", nil); err == nil {
+		t.Error("retryClient error = nil, want error when context is canceled")
+	}
+}
@@ -0,0 +1,58 @@
+package github
+
+import "context"
+
+// NOTE: https://docs.github.com/en/rest/reference/pulls#reviews
+const (
+	ReviewStateApproved         = "APPROVED"
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	ReviewStateDismissed        = "DISMISSED"
+)
+
+const maxReviewsPerPage = 100
+
+// ListReviews returns the reviews of the pull request in chronological order.
+func ListReviews(ctx context.Context, c Client, owner, repo string, number int) ([]*PullRequestReview, error) {
+	var reviews []*PullRequestReview
+	err := Paginate(MaxPages, "reviews", func(page int) (*Response, error) {
+		rs, resp, err := c.ListReviews(ctx, owner, repo, number, &ListOptions{
+			Page:    page,
+			PerPage: maxReviewsPerPage,
+		})
+		reviews = append(reviews, rs...)
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// LatestReviews returns the latest review of each reviewer in the order of their first reviews.
+// Comments do not override approvals or change requests, and reviews by the author are not counted.
+func LatestReviews(reviews []*PullRequestReview, author string) []*PullRequestReview {
+	var logins []string
+	latest := make(map[string]*PullRequestReview)
+	for _, r := range reviews {
+		login := r.GetUser().GetLogin()
+		if len(login) == 0 || login == author {
+			continue
+		}
+		switch r.GetState() {
+		case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateDismissed:
+		default:
+			continue
+		}
+
+		if _, ok := latest[login]; !ok {
+			logins = append(logins, login)
+		}
+		latest[login] = r
+	}
+
+	result := make([]*PullRequestReview, 0, len(logins))
+	for _, login := range logins {
+		result = append(result, latest[login])
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLatestReviews(t *testing.T) {
+	review := func(login, state string) *PullRequestReview {
+		return &PullRequestReview{User: &User{Login: &login}, State: &state}
+	}
+	approvedByAlice := review("alice", ReviewStateApproved)
+	commentedByAlice := review("alice", "COMMENTED")
+	approvedByBob := review("bob", ReviewStateApproved)
+	changesRequestedByBob := review("bob", ReviewStateChangesRequested)
+	approvedByAuthor := review("author", ReviewStateApproved)
+	dismissedByCarol := review("carol", ReviewStateDismissed)
+
+	got := LatestReviews([]*PullRequestReview{
+		approvedByBob,
+		approvedByAlice,
+		commentedByAlice,
+		changesRequestedByBob,
+		approvedByAuthor,
+		dismissedByCarol,
+	}, "author")
+	want := []*PullRequestReview{changesRequestedByBob, approvedByAlice, dismissedByCarol}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LatestReviews() = %v, want %v", got, want)
+	}
+}
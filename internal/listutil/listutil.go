@@ -0,0 +1,16 @@
+package listutil
+
+import "strings"
+
+// Split splits a comma-separated list, skipping empty entries.
+func Split(str string) []string {
+	list := []string{}
+	for _, s := range strings.Split(str, ",") {
+		s = strings.TrimSpace(s)
+		if len(s) == 0 {
+			continue
+		}
+		list = append(list, s)
+	}
+	return list
+}
@@ -0,0 +1,127 @@
+package ticker
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// BackoffConfig configures the intervals of the backoff ticker.
+// The interval starts from Initial, is multiplied by Multiplier after each tick up to Max,
+// and is randomized by Jitter, which is the ratio of the interval, such as 0.1 for ±10%.
+type BackoffConfig struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+func (c BackoffConfig) validate() error {
+	if c.Initial <= 0 {
+		return errors.New("initial interval must be positive")
+	}
+	if c.Max < c.Initial {
+		return errors.New("max interval must not be less than initial interval")
+	}
+	if c.Multiplier < 1 {
+		return errors.New("multiplier must be 1 or greater")
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return errors.New("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// clock abstracts time so that tests can drive the ticker.
+type clock interface {
+	Now() time.Time
+	// NewTimer returns the channel which fires after d, and the function to stop the timer.
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(d)
+	return t.C, t.Stop
+}
+
+type backoffTicker struct {
+	cfg    BackoffConfig
+	clock  clock
+	random func() float64 // returns a number in [0.0, 1.0)
+
+	tch      chan time.Time
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewBackoffTicker returns a ticker which ticks instantly, and then with exponentially increasing intervals.
+// Like time.Ticker, ticks are dropped when the receiver is not ready.
+func NewBackoffTicker(cfg BackoffConfig) (InstantTicker, error) {
+	return newBackoffTicker(cfg, realClock{}, rand.Float64)
+}
+
+func newBackoffTicker(cfg BackoffConfig, c clock, random func() float64) (*backoffTicker, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	bt := &backoffTicker{
+		cfg:    cfg,
+		clock:  c,
+		random: random,
+		tch:    make(chan time.Time, 1),
+		done:   make(chan struct{}),
+	}
+	bt.tch <- c.Now()
+	go bt.run()
+	return bt, nil
+}
+
+func (bt *backoffTicker) run() {
+	interval := bt.cfg.Initial
+	for {
+		// The timer is stopped on Stop, so that a long interval does not keep it alive.
+		ch, stop := bt.clock.NewTimer(bt.jitter(interval))
+		select {
+		case <-bt.done:
+			stop()
+			return
+		case t := <-ch:
+			select {
+			case bt.tch <- t:
+			default:
+			}
+		}
+		interval = bt.next(interval)
+	}
+}
+
+func (bt *backoffTicker) next(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * bt.cfg.Multiplier)
+	if next > bt.cfg.Max || next < interval { // The latter is for overflow
+		return bt.cfg.Max
+	}
+	return next
+}
+
+func (bt *backoffTicker) jitter(interval time.Duration) time.Duration {
+	if bt.cfg.Jitter == 0 {
+		return interval
+	}
+	delta := bt.cfg.Jitter * (2*bt.random() - 1)
+	return time.Duration(float64(interval) * (1 + delta))
+}
+
+func (bt *backoffTicker) Stop() {
+	bt.stopOnce.Do(func() {
+		close(bt.done)
+	})
+}
+
+func (bt *backoffTicker) C() <-chan time.Time {
+	return bt.tch
+}
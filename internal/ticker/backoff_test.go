@@ -0,0 +1,166 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock lets tests observe the requested intervals, and fire them manually.
+type fakeClock struct {
+	now    time.Time
+	afters chan fakeAfter
+}
+
+type fakeAfter struct {
+	d       time.Duration
+	ch      chan time.Time
+	stopped chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		afters: make(chan fakeAfter),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	a := fakeAfter{d: d, ch: make(chan time.Time, 1), stopped: make(chan struct{})}
+	c.afters <- a
+	return a.ch, func() bool {
+		close(a.stopped)
+		return true
+	}
+}
+
+// advance waits for the ticker to request the next interval, and fires it.
+func (c *fakeClock) advance(t *testing.T) time.Duration {
+	t.Helper()
+	select {
+	case a := <-c.afters:
+		c.now = c.now.Add(a.d)
+		a.ch <- c.now
+		return a.d
+	case <-time.After(time.Second):
+		t.Fatal("ticker did not wait for the next interval")
+		return 0
+	}
+}
+
+func TestBackoffTicker(t *testing.T) {
+	tests := map[string]struct {
+		cfg    BackoffConfig
+		random float64
+		want   []time.Duration
+	}{
+		"increases interval up to max": {
+			cfg: BackoffConfig{
+				Initial:    5 * time.Second,
+				Max:        30 * time.Second,
+				Multiplier: 2,
+			},
+			want: []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second},
+		},
+		"keeps interval with multiplier 1": {
+			cfg: BackoffConfig{
+				Initial:    5 * time.Second,
+				Max:        5 * time.Second,
+				Multiplier: 1,
+			},
+			want: []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		"randomizes interval with jitter": {
+			cfg: BackoffConfig{
+				Initial:    10 * time.Second,
+				Max:        40 * time.Second,
+				Multiplier: 2,
+				Jitter:     0.5,
+			},
+			random: 0.75, // +25%
+			want:   []time.Duration{12500 * time.Millisecond, 25 * time.Second, 50 * time.Second, 50 * time.Second},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newFakeClock()
+			bt, err := newBackoffTicker(tt.cfg, c, func() float64 { return tt.random })
+			if err != nil {
+				t.Fatalf("newBackoffTicker() error = %v", err)
+			}
+			defer bt.Stop()
+
+			if got := <-bt.C(); !got.Equal(c.now) {
+				t.Errorf("first tick = %v, want instant tick at %v", got, c.now)
+			}
+			for i, want := range tt.want {
+				if got := c.advance(t); got != want {
+					t.Errorf("interval[%d] = %v, want %v", i, got, want)
+				}
+				if got := <-bt.C(); !got.Equal(c.now) {
+					t.Errorf("tick[%d] = %v, want %v", i, got, c.now)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffTicker_Stop(t *testing.T) {
+	c := newFakeClock()
+	bt, err := newBackoffTicker(BackoffConfig{Initial: time.Second, Max: time.Second, Multiplier: 1}, c, func() float64 { return 0 })
+	if err != nil {
+		t.Fatalf("newBackoffTicker() error = %v", err)
+	}
+	<-bt.C()
+	a := <-c.afters
+	bt.Stop()
+	bt.Stop()
+
+	select {
+	case <-bt.done:
+	default:
+		t.Error("ticker is not stopped")
+	}
+	select {
+	case <-a.stopped:
+	case <-time.After(time.Second):
+		t.Error("timer of the next interval is not stopped")
+	}
+}
+
+func TestBackoffConfig_validate(t *testing.T) {
+	tests := map[string]struct {
+		cfg     BackoffConfig
+		wantErr bool
+	}{
+		"returns nil when config is valid": {
+			cfg: BackoffConfig{Initial: time.Second, Max: time.Minute, Multiplier: 1.5, Jitter: 0.1},
+		},
+		"returns error when initial interval is zero": {
+			cfg:     BackoffConfig{Max: time.Minute, Multiplier: 2},
+			wantErr: true,
+		},
+		"returns error when max interval is less than initial interval": {
+			cfg:     BackoffConfig{Initial: time.Minute, Max: time.Second, Multiplier: 2},
+			wantErr: true,
+		},
+		"returns error when multiplier is less than 1": {
+			cfg:     BackoffConfig{Initial: time.Second, Max: time.Minute, Multiplier: 0.5},
+			wantErr: true,
+		},
+		"returns error when jitter is greater than 1": {
+			cfg:     BackoffConfig{Initial: time.Second, Max: time.Minute, Multiplier: 2, Jitter: 1.5},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tt.cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("BackoffConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
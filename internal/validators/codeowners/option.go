@@ -0,0 +1,22 @@
+package codeowners
+
+type Option func(s *codeOwnersValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(s *codeOwnersValidator) {
+		if len(owner) != 0 {
+			s.owner = owner
+		}
+		if len(repo) != 0 {
+			s.repo = repo
+		}
+	}
+}
+
+func WithPullRequestNumber(number uint) Option {
+	return func(s *codeOwnersValidator) {
+		if number != 0 {
+			s.number = int(number)
+		}
+	}
+}
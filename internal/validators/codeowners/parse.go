@@ -0,0 +1,129 @@
+package codeowners
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// rule is a line of CODEOWNERS, which assigns owners to the files matching the pattern.
+// NOTE: https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+type rule struct {
+	line    int
+	pattern string
+	re      *regexp.Regexp
+	users   []string // logins without "@"
+	teams   []string // "org/team-slug" without "@"
+}
+
+func (r *rule) hasOwners() bool {
+	return len(r.users) != 0 || len(r.teams) != 0
+}
+
+func (r *rule) String() string {
+	owners := make([]string, 0, len(r.users)+len(r.teams))
+	for _, u := range r.users {
+		owners = append(owners, "@"+u)
+	}
+	for _, t := range r.teams {
+		owners = append(owners, "@"+t)
+	}
+	return fmt.Sprintf("%s %s", r.pattern, strings.Join(owners, " "))
+}
+
+// parseCodeOwners parses CODEOWNERS file, and returns the rules along with the lines which cannot be parsed.
+// Invalid lines are skipped as GitHub does, so that they do not block pull requests which the other rules apply to.
+// Owners by email address are skipped, because they cannot be matched to reviewers.
+func parseCodeOwners(content string) ([]*rule, []string) {
+	var rules []*rule
+	var unsupported []string
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		re, err := compilePattern(pattern)
+		if err != nil {
+			unsupported = append(unsupported, fmt.Sprintf("line %d: %v", i+1, err))
+			continue
+		}
+		r := &rule{line: i + 1, pattern: pattern, re: re}
+		for _, owner := range fields[1:] {
+			if !strings.HasPrefix(owner, "@") {
+				continue
+			}
+			owner = strings.TrimPrefix(owner, "@")
+			if strings.Contains(owner, "/") {
+				r.teams = append(r.teams, owner)
+			} else {
+				r.users = append(r.users, owner)
+			}
+		}
+		rules = append(rules, r)
+	}
+	return rules, unsupported
+}
+
+// compilePattern converts the gitignore-style pattern of CODEOWNERS to the regular expression matching file paths.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("pattern %q is not supported by CODEOWNERS", pattern)
+	}
+
+	p := pattern
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	// A pattern with a slash at the beginning or middle is relative to the root, and others match at any depth.
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case p[i] == '*':
+			sb.WriteString("[^/]*")
+		case p[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+
+	lastSegment := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		sb.WriteString("/.*")
+	case !strings.Contains(lastSegment, "*"):
+		// A pattern matching a directory also matches all the files in it.
+		// A wildcard in the last segment only matches files in the directory, such as "docs/*".
+		sb.WriteString("(?:/.*)?")
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// ownerRule returns the last rule matching the file, which takes precedence.
+func ownerRule(rules []*rule, file string) *rule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(file) {
+			return rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package codeowners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_compilePattern(t *testing.T) {
+	tests := map[string]struct {
+		pattern   string
+		matches   []string
+		unmatches []string
+		wantErr   bool
+	}{
+		"matches all files with *": {
+			pattern: "*",
+			matches: []string{"README.md", "internal/cli/cli.go"},
+		},
+		"matches files with the extension at any depth": {
+			pattern:   "*.go",
+			matches:   []string{"main.go", "internal/cli/cli.go"},
+			unmatches: []string{"go.mod", "main.go.txt"},
+		},
+		"matches directory at any depth without leading slash": {
+			pattern:   "docs/",
+			matches:   []string{"docs/README.md", "internal/docs/a/b.md"},
+			unmatches: []string{"docs", "mydocs/a.md"},
+		},
+		"matches directory at the root with leading slash": {
+			pattern:   "/docs/",
+			matches:   []string{"docs/README.md", "docs/a/b.md"},
+			unmatches: []string{"internal/docs/a.md"},
+		},
+		"matches path relative to the root with slash in the middle": {
+			pattern:   "internal/cli",
+			matches:   []string{"internal/cli/cli.go", "internal/cli/a/b.go"},
+			unmatches: []string{"a/internal/cli/cli.go", "internal/client.go"},
+		},
+		"matches files directly in the directory with trailing *": {
+			pattern:   "docs/*",
+			matches:   []string{"docs/README.md"},
+			unmatches: []string{"docs/a/b.md"},
+		},
+		"matches nested directories with **": {
+			pattern:   "**/logs",
+			matches:   []string{"logs/a.log", "build/logs/a.log"},
+			unmatches: []string{"build/logsfile"},
+		},
+		"matches any depth in the middle with **": {
+			pattern:   "/apps/**/test.go",
+			matches:   []string{"apps/test.go", "apps/a/b/test.go"},
+			unmatches: []string{"apps/a/b/test.go.txt"},
+		},
+		"matches single character with ?": {
+			pattern:   "v?.txt",
+			matches:   []string{"v1.txt", "a/v2.txt"},
+			unmatches: []string{"v10.txt"},
+		},
+		"returns error when pattern is negated": {
+			pattern: "!*.go",
+			wantErr: true,
+		},
+		"returns error when pattern has character range": {
+			pattern: "[a-z].go",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			re, err := compilePattern(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compilePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, m := range tt.matches {
+				if !re.MatchString(m) {
+					t.Errorf("compilePattern(%q) does not match %q, regexp: %s", tt.pattern, m, re)
+				}
+			}
+			for _, m := range tt.unmatches {
+				if re.MatchString(m) {
+					t.Errorf("compilePattern(%q) matches %q, regexp: %s", tt.pattern, m, re)
+				}
+			}
+		})
+	}
+}
+
+func Test_parseCodeOwners(t *testing.T) {
+	tests := map[string]struct {
+		content         string
+		want            []*rule
+		wantUnsupported []string
+	}{
+		"returns rules with users and teams": {
+			content: `# Default owners
+*       @alice
+
+/docs/  @upsidr/docs-team docs@example.com # inline comment
+*.go    @bob @upsidr/go-team
+/build/ docs@example.com
+`,
+			want: []*rule{
+				{line: 2, pattern: "*", users: []string{"alice"}},
+				{line: 4, pattern: "/docs/", teams: []string{"upsidr/docs-team"}},
+				{line: 5, pattern: "*.go", users: []string{"bob"}, teams: []string{"upsidr/go-team"}},
+				{line: 6, pattern: "/build/"},
+			},
+		},
+		"skips lines with unsupported patterns": {
+			content: "*.go @a\n/docs/[ab]*.md @b\n!*.txt @c\n",
+			want: []*rule{
+				{line: 1, pattern: "*.go", users: []string{"a"}},
+			},
+			wantUnsupported: []string{
+				`line 2: pattern "/docs/[ab]*.md" is not supported by CODEOWNERS`,
+				`line 3: pattern "!*.txt" is not supported by CODEOWNERS`,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotUnsupported := parseCodeOwners(tt.content)
+			for _, r := range got {
+				r.re = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCodeOwners() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotUnsupported, tt.wantUnsupported) {
+				t.Errorf("parseCodeOwners() unsupported = %v, want %v", gotUnsupported, tt.wantUnsupported)
+			}
+		})
+	}
+}
+
+func Test_ownerRule(t *testing.T) {
+	rules, _ := parseCodeOwners(`*      @alice
+*.go   @bob
+/docs/ @carol
+`)
+	tests := map[string]struct {
+		file string
+		want string
+	}{
+		"returns the default rule":                 {file: "README.md", want: "*"},
+		"returns the last matching rule":           {file: "internal/cli/cli.go", want: "*.go"},
+		"returns the last rule over earlier rules": {file: "docs/example.go", want: "/docs/"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ownerRule(rules, tt.file)
+			if got == nil || got.pattern != tt.want {
+				t.Errorf("ownerRule() = %v, want pattern %s", got, tt.want)
+			}
+		})
+	}
+}
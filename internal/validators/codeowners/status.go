@@ -0,0 +1,64 @@
+package codeowners
+
+import (
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+type status struct {
+	path          string // path of CODEOWNERS, which is empty when it is not found
+	approvedRules []string
+	unmetRules    []string // rules without approval from their owners, along with the files they own
+	// unsupportedRules holds the lines of CODEOWNERS which are skipped, because their patterns are not supported.
+	unsupportedRules []string
+
+	succeeded bool
+}
+
+func (s *status) Detail() string {
+	if len(s.path) == 0 {
+		return "CODEOWNERS file is not found, no code owner approval is required\n"
+	}
+
+	result := fmt.Sprintf(
+		`%d out of %d code owner rules approved
+
+CODEOWNERS: %s
+
+::group::Unmet rules
+%s
+::endgroup::
+
+::group::Approved rules
+%s
+::endgroup::
+`,
+		len(s.approvedRules), len(s.approvedRules)+len(s.unmetRules),
+		s.path,
+		validators.PrettyPrintList(s.unmetRules),
+		validators.PrettyPrintList(s.approvedRules),
+	)
+
+	if len(s.unsupportedRules) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Unsupported rules
+%s
+::endgroup::
+`,
+			result,
+			validators.PrettyPrintList(s.unsupportedRules),
+		)
+	}
+
+	return result
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
+
+// Jobs returns nil, because the validator does not check any job.
+func (s *status) Jobs() []*validators.Job {
+	return nil
+}
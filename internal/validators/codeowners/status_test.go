@@ -0,0 +1,64 @@
+package codeowners
+
+import "testing"
+
+func Test_status_Detail(t *testing.T) {
+	tests := map[string]struct {
+		s    *status
+		want string
+	}{
+		"returns detail with approved and unmet rules": {
+			s: &status{
+				path:          ".github/CODEOWNERS",
+				approvedRules: []string{"*.go @bob (approved by bob)"},
+				unmetRules:    []string{"/docs/ @upsidr/docs-team: docs/README.md"},
+			},
+			want: `1 out of 2 code owner rules approved
+
+CODEOWNERS: .github/CODEOWNERS
+
+::group::Unmet rules
+- /docs/ @upsidr/docs-team: docs/README.md
+::endgroup::
+
+::group::Approved rules
+- *.go @bob (approved by bob)
+::endgroup::
+`,
+		},
+		"returns detail with unsupported rules": {
+			s: &status{
+				path:             "CODEOWNERS",
+				unsupportedRules: []string{`line 2: pattern "/docs/[ab]*.md" is not supported by CODEOWNERS`},
+				succeeded:        true,
+			},
+			want: `0 out of 0 code owner rules approved
+
+CODEOWNERS: CODEOWNERS
+
+::group::Unmet rules
+[]
+::endgroup::
+
+::group::Approved rules
+[]
+::endgroup::
+
+::group::Unsupported rules
+- line 2: pattern "/docs/[ab]*.md" is not supported by CODEOWNERS
+::endgroup::
+`,
+		},
+		"returns detail without CODEOWNERS": {
+			s:    &status{succeeded: true},
+			want: "CODEOWNERS file is not found, no code owner approval is required\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.s.Detail(); got != tt.want {
+				t.Errorf("status.Detail() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
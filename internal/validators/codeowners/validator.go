@@ -0,0 +1,251 @@
+package codeowners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "code-owners"
+
+const maxItemsPerPage = 100
+
+// codeOwnersPaths are the locations of CODEOWNERS file, in the order of precedence.
+var codeOwnersPaths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+var ErrInvalidPullRequestResponse = errors.New("github pull request response is invalid")
+
+type codeOwnersValidator struct {
+	repo   string
+	owner  string
+	number int
+	client github.Client
+}
+
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	cv := &codeOwnersValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(cv)
+	}
+	if err := cv.validateFields(); err != nil {
+		return nil, err
+	}
+	return cv, nil
+}
+
+func (cv *codeOwnersValidator) Name() string {
+	return validatorName
+}
+
+func (cv *codeOwnersValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(cv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(cv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if cv.number <= 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if cv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (cv *codeOwnersValidator) Validate(ctx context.Context) (validators.Status, error) {
+	pr, _, err := cv.client.GetPullRequest(ctx, cv.owner, cv.repo, cv.number)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil || pr.GetBase().GetRef() == "" {
+		return nil, ErrInvalidPullRequestResponse
+	}
+
+	// CODEOWNERS is read from the base branch, so that the pull request cannot change its own owners.
+	path, content, err := cv.getCodeOwners(ctx, pr.GetBase().GetRef())
+	if err != nil {
+		return nil, err
+	}
+	st := &status{
+		path:      path,
+		succeeded: true,
+	}
+	if len(path) == 0 {
+		return st, nil
+	}
+
+	rules, unsupported := parseCodeOwners(content)
+	st.unsupportedRules = unsupported
+
+	files, err := cv.listFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the changed files by the rules which own them.
+	var matched []*rule
+	filesByRule := make(map[*rule][]string)
+	for _, file := range files {
+		r := ownerRule(rules, file)
+		if r == nil || !r.hasOwners() {
+			continue
+		}
+		if _, ok := filesByRule[r]; !ok {
+			matched = append(matched, r)
+		}
+		filesByRule[r] = append(filesByRule[r], file)
+	}
+	if len(matched) == 0 {
+		return st, nil
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].line < matched[j].line })
+
+	reviews, err := github.ListReviews(ctx, cv.client, cv.owner, cv.repo, cv.number)
+	if err != nil {
+		return nil, err
+	}
+	var approvers []string
+	for _, r := range github.LatestReviews(reviews, pr.GetUser().GetLogin()) {
+		if r.GetState() == github.ReviewStateApproved {
+			approvers = append(approvers, r.GetUser().GetLogin())
+		}
+	}
+
+	members := make(map[string]map[string]struct{}) // team -> logins
+	for _, r := range matched {
+		approvedBy, err := cv.approvedBy(ctx, r, approvers, members)
+		if err != nil {
+			return nil, err
+		}
+		if len(approvedBy) != 0 {
+			st.approvedRules = append(st.approvedRules, fmt.Sprintf("%s (approved by %s)", r, strings.Join(approvedBy, ", ")))
+			continue
+		}
+		st.unmetRules = append(st.unmetRules, fmt.Sprintf("%s: %s", r, strings.Join(filesByRule[r], ", ")))
+	}
+	st.succeeded = len(st.unmetRules) == 0
+
+	return st, nil
+}
+
+// approvedBy returns the approvers who own the files by the rule.
+func (cv *codeOwnersValidator) approvedBy(ctx context.Context, r *rule, approvers []string, members map[string]map[string]struct{}) ([]string, error) {
+	var approvedBy []string
+	for _, approver := range approvers {
+		for _, user := range r.users {
+			if strings.EqualFold(user, approver) {
+				approvedBy = append(approvedBy, approver)
+			}
+		}
+	}
+	if len(approvedBy) != 0 {
+		return approvedBy, nil
+	}
+
+	for _, team := range r.teams {
+		logins, ok := members[team]
+		if !ok {
+			var err error
+			logins, err = cv.listTeamMembers(ctx, team)
+			if err != nil {
+				return nil, err
+			}
+			members[team] = logins
+		}
+		for _, approver := range approvers {
+			if _, ok := logins[strings.ToLower(approver)]; ok {
+				approvedBy = append(approvedBy, fmt.Sprintf("%s in @%s", approver, team))
+			}
+		}
+	}
+	return approvedBy, nil
+}
+
+// getCodeOwners returns the path and content of CODEOWNERS, or empty path when there is no CODEOWNERS.
+func (cv *codeOwnersValidator) getCodeOwners(ctx context.Context, ref string) (string, string, error) {
+	for _, path := range codeOwnersPaths {
+		file, resp, err := cv.client.GetContents(ctx, cv.owner, cv.repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return path, content, nil
+	}
+	return "", "", nil
+}
+
+func (cv *codeOwnersValidator) listFiles(ctx context.Context) ([]string, error) {
+	var files []string
+	err := github.Paginate(github.MaxPages, "files", func(page int) (*github.Response, error) {
+		fs, resp, err := cv.client.ListPullRequestFiles(ctx, cv.owner, cv.repo, cv.number, &github.ListOptions{
+			Page:    page,
+			PerPage: maxItemsPerPage,
+		})
+		for _, f := range fs {
+			files = append(files, f.GetFilename())
+			// Renamed files are owned by the owners of both paths.
+			if prev := f.GetPreviousFilename(); len(prev) != 0 {
+				files = append(files, prev)
+			}
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// listTeamMembers returns the lower-cased logins of the team members.
+func (cv *codeOwnersValidator) listTeamMembers(ctx context.Context, team string) (map[string]struct{}, error) {
+	sp := strings.SplitN(team, "/", 2)
+	org, slug := sp[0], sp[1]
+
+	logins := make(map[string]struct{})
+	err := github.Paginate(github.MaxPages, "members of @"+team, func(page int) (*github.Response, error) {
+		users, resp, err := cv.client.ListTeamMembersBySlug(ctx, org, slug, &github.TeamListTeamMembersOptions{
+			ListOptions: github.ListOptions{
+				Page:    page,
+				PerPage: maxItemsPerPage,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members of @%s: %w", team, err)
+		}
+		for _, u := range users {
+			logins[strings.ToLower(u.GetLogin())] = struct{}{}
+		}
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logins, nil
+}
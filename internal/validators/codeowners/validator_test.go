@@ -0,0 +1,263 @@
+package codeowners
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func review(login, state string) *github.PullRequestReview {
+	return &github.PullRequestReview{
+		User:  &github.User{Login: stringPtr(login)},
+		State: stringPtr(state),
+	}
+}
+
+func pullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return &github.PullRequest{
+		User: &github.User{Login: stringPtr("author")},
+		Base: &github.PullRequestBranch{Ref: stringPtr("main")},
+	}, nil, nil
+}
+
+func codeOwnersAt(at, content string) func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error) {
+	return func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error) {
+		if path != at || opts.Ref != "main" {
+			return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+		}
+		return &github.RepositoryContent{Content: stringPtr(content)}, nil, nil
+	}
+}
+
+func files(names ...string) func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	return func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+		fs := make([]*github.CommitFile, 0, len(names))
+		for _, name := range names {
+			fs = append(fs, &github.CommitFile{Filename: stringPtr(name)})
+		}
+		return fs, nil, nil
+	}
+}
+
+func reviews(rs ...*github.PullRequestReview) func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+	return func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		return rs, nil, nil
+	}
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		wantErr bool
+	}{
+		"returns Validator when options are valid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+			},
+			wantErr: false,
+		},
+		"returns error when pull request number is empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+			},
+			wantErr: true,
+		},
+		"returns error when client is nil": {
+			c: nil,
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_codeOwnersValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		client     github.Client
+		wantErr    bool
+		wantStatus *status
+	}{
+		"returns error when pull request cannot be fetched": {
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns error when CODEOWNERS cannot be fetched": {
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest,
+				GetContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns succeeded status when CODEOWNERS is not found": {
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest,
+				GetContentsFunc:    codeOwnersAt("", ""),
+			},
+			wantStatus: &status{
+				succeeded: true,
+			},
+		},
+		"returns succeeded status when changed files have no owners": {
+			client: &mock.Client{
+				GetPullRequestFunc:       pullRequest,
+				GetContentsFunc:          codeOwnersAt("CODEOWNERS", "/docs/ @alice\n/build/ build@example.com\n"),
+				ListPullRequestFilesFunc: files("main.go", "build/Dockerfile"),
+			},
+			wantStatus: &status{
+				path:      "CODEOWNERS",
+				succeeded: true,
+			},
+		},
+		"returns succeeded status when all rules are approved by users and team members": {
+			client: &mock.Client{
+				GetPullRequestFunc:       pullRequest,
+				GetContentsFunc:          codeOwnersAt(".github/CODEOWNERS", "*.go @Bob\n/docs/ @upsidr/docs-team\n"),
+				ListPullRequestFilesFunc: files("main.go", "docs/README.md", "docs/example.md"),
+				ListReviewsFunc: reviews(
+					review("bob", github.ReviewStateApproved),
+					review("carol", "COMMENTED"),
+					review("carol", github.ReviewStateApproved),
+				),
+				ListTeamMembersBySlugFunc: func(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+					if org != "upsidr" || slug != "docs-team" {
+						return nil, nil, errors.New("unexpected team")
+					}
+					return []*github.User{{Login: stringPtr("Carol")}}, nil, nil
+				},
+			},
+			wantStatus: &status{
+				path: ".github/CODEOWNERS",
+				approvedRules: []string{
+					"*.go @Bob (approved by bob)",
+					"/docs/ @upsidr/docs-team (approved by carol in @upsidr/docs-team)",
+				},
+				succeeded: true,
+			},
+		},
+		"returns pending status when the owner did not approve": {
+			client: &mock.Client{
+				GetPullRequestFunc:       pullRequest,
+				GetContentsFunc:          codeOwnersAt("docs/CODEOWNERS", "* @alice\n*.go @bob\n"),
+				ListPullRequestFilesFunc: files("main.go", "internal/cli/cli.go", "README.md"),
+				ListReviewsFunc: reviews(
+					review("alice", github.ReviewStateApproved),
+					review("bob", github.ReviewStateApproved),
+					review("bob", github.ReviewStateChangesRequested),
+					review("author", github.ReviewStateApproved),
+				),
+			},
+			wantStatus: &status{
+				path:          "docs/CODEOWNERS",
+				approvedRules: []string{"* @alice (approved by alice)"},
+				unmetRules:    []string{"*.go @bob: main.go, internal/cli/cli.go"},
+				succeeded:     false,
+			},
+		},
+		"returns status with unsupported rules skipped": {
+			client: &mock.Client{
+				GetPullRequestFunc:       pullRequest,
+				GetContentsFunc:          codeOwnersAt("CODEOWNERS", "*.go @a\n/docs/[ab]*.md @b\n"),
+				ListPullRequestFilesFunc: files("main.go", "docs/a.md"),
+				ListReviewsFunc:          reviews(review("a", github.ReviewStateApproved)),
+			},
+			wantStatus: &status{
+				path:             "CODEOWNERS",
+				approvedRules:    []string{"*.go @a (approved by a)"},
+				unsupportedRules: []string{`line 2: pattern "/docs/[ab]*.md" is not supported by CODEOWNERS`},
+				succeeded:        true,
+			},
+		},
+		"returns pending status when the author is the only owner": {
+			client: &mock.Client{
+				GetPullRequestFunc:       pullRequest,
+				GetContentsFunc:          codeOwnersAt("CODEOWNERS", "* @author\n"),
+				ListPullRequestFilesFunc: files("main.go"),
+				ListReviewsFunc:          reviews(review("author", github.ReviewStateApproved)),
+			},
+			wantStatus: &status{
+				path:       "CODEOWNERS",
+				unmetRules: []string{"* @author: main.go"},
+				succeeded:  false,
+			},
+		},
+		"returns error when team members cannot be listed": {
+			client: &mock.Client{
+				GetPullRequestFunc:       pullRequest,
+				GetContentsFunc:          codeOwnersAt("CODEOWNERS", "* @upsidr/team\n"),
+				ListPullRequestFilesFunc: files("main.go"),
+				ListReviewsFunc:          reviews(review("alice", github.ReviewStateApproved)),
+				ListTeamMembersBySlugFunc: func(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns succeeded status with files across pages": {
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest,
+				GetContentsFunc:    codeOwnersAt("CODEOWNERS", "/docs/ @alice\n"),
+				ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+					if opts.Page == 1 {
+						return []*github.CommitFile{{Filename: stringPtr("main.go")}}, &github.Response{NextPage: 2}, nil
+					}
+					return []*github.CommitFile{{Filename: stringPtr("docs/README.md")}}, &github.Response{}, nil
+				},
+				ListReviewsFunc: reviews(review("alice", github.ReviewStateApproved)),
+			},
+			wantStatus: &status{
+				path:          "CODEOWNERS",
+				approvedRules: []string{"/docs/ @alice (approved by alice)"},
+				succeeded:     true,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cv := &codeOwnersValidator{
+				owner:  "test-owner",
+				repo:   "test-repo",
+				number: 1,
+				client: tt.client,
+			}
+			got, err := cv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("codeOwnersValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("codeOwnersValidator.Validate() status = %+v, want %+v", got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package commitlint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// workInProgressRegexp matches subjects of work in progress commits, such as "WIP: add validator" or "fixup! Add validator".
+	workInProgressRegexp = regexp.MustCompile(`(?i)^(?:fixup!|squash!|amend!|\[?wip\b)`)
+
+	// trailerRegexp matches git trailers, such as "Refs: #123".
+	trailerRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*)$`)
+
+	// issueReferenceRegexp matches references to issues, such as "#123", "upsidr/merge-gatekeeper#123", "ABC-123" or URLs.
+	issueReferenceRegexp = regexp.MustCompile(`(?:[\w.-]+/[\w.-]+)?#\d+|\b[A-Z][A-Z0-9]+-\d+\b|https?://\S+`)
+)
+
+// subject returns the first line of the commit message.
+func subject(message string) string {
+	return strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+}
+
+// trailers returns the trailers in the last paragraph of the commit message, which is not the subject.
+func trailers(message string) map[string][]string {
+	paragraphs := strings.Split(strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n")), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	result := make(map[string][]string)
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		m := trailerRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		key := strings.ToLower(m[1])
+		result[key] = append(result[key], m[2])
+	}
+	return result
+}
+
+// violations returns the violations of the commit message against the rules.
+func (cv *commitLintValidator) violations(message string) []string {
+	subj := subject(message)
+
+	var violations []string
+	if cv.maxSubjectLength > 0 {
+		if n := utf8.RuneCountInString(subj); n > cv.maxSubjectLength {
+			violations = append(violations, fmt.Sprintf("subject has %d characters, which is longer than %d", n, cv.maxSubjectLength))
+		}
+	}
+	if cv.subjectPattern != nil && !cv.subjectPattern.MatchString(subj) {
+		violations = append(violations, fmt.Sprintf("subject does not match pattern %q", cv.subjectPattern))
+	}
+	if cv.noWorkInProgress && workInProgressRegexp.MatchString(subj) {
+		violations = append(violations, "work in progress commit needs to be squashed")
+	}
+	if len(cv.issueTrailers) != 0 && !cv.hasIssueReference(trailers(message)) {
+		violations = append(violations, fmt.Sprintf("issue reference trailer is missing, such as \"%s: #123\"", cv.issueTrailers[0]))
+	}
+	return violations
+}
+
+func (cv *commitLintValidator) hasIssueReference(trailers map[string][]string) bool {
+	for _, key := range cv.issueTrailers {
+		for _, value := range trailers[strings.ToLower(key)] {
+			if issueReferenceRegexp.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package commitlint
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func Test_trailers(t *testing.T) {
+	tests := map[string]struct {
+		message string
+		want    map[string][]string
+	}{
+		"returns trailers in the last paragraph": {
+			message: "Add validator\n\nRefs: this is not a trailer\n\nRefs: #123\nSigned-off-by: Alice <alice@example.com>\n",
+			want: map[string][]string{
+				"refs":          {"#123"},
+				"signed-off-by": {"Alice <alice@example.com>"},
+			},
+		},
+		"returns nil when there is only subject": {
+			message: "Refs: #123",
+			want:    nil,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := trailers(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trailers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commitLintValidator_violations(t *testing.T) {
+	cv := &commitLintValidator{
+		maxSubjectLength: 20,
+		subjectPattern:   regexp.MustCompile(`^(Add|Fix|Remove|Update) `),
+		noWorkInProgress: true,
+		issueTrailers:    []string{"Refs", "Fixes"},
+	}
+	tests := map[string]struct {
+		message string
+		want    []string
+	}{
+		"returns nil when message follows the rules": {
+			message: "Add validator\n\nSome details.\n\nfixes: upsidr/merge-gatekeeper#123",
+		},
+		"returns nil when trailer references issue key": {
+			message: "Fix bug\n\nRefs: ABC-123",
+		},
+		"returns violations when subject is long and not imperative": {
+			message: "Added the commit lint validator\n\nRefs: #123",
+			want: []string{
+				"subject has 31 characters, which is longer than 20",
+				`subject does not match pattern "^(Add|Fix|Remove|Update) "`,
+			},
+		},
+		"returns violations when commit is work in progress without issue reference": {
+			message: "fixup! Add validator\n\nRefs: soon",
+			want: []string{
+				`subject does not match pattern "^(Add|Fix|Remove|Update) "`,
+				"work in progress commit needs to be squashed",
+				`issue reference trailer is missing, such as "Refs: #123"`,
+			},
+		},
+		"returns violation when subject starts with WIP": {
+			message: "[WIP] Add validator",
+			want: []string{
+				`subject does not match pattern "^(Add|Fix|Remove|Update) "`,
+				"work in progress commit needs to be squashed",
+				`issue reference trailer is missing, such as "Refs: #123"`,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := cv.violations(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package commitlint
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/upsidr/merge-gatekeeper/internal/listutil"
+)
+
+type Option func(s *commitLintValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(s *commitLintValidator) {
+		if len(owner) != 0 {
+			s.owner = owner
+		}
+		if len(repo) != 0 {
+			s.repo = repo
+		}
+	}
+}
+
+func WithPullRequestNumber(number uint) Option {
+	return func(s *commitLintValidator) {
+		if number != 0 {
+			s.number = int(number)
+		}
+	}
+}
+
+// WithMaxSubjectLength sets the max number of characters in the subject, which is the first line of the commit message.
+func WithMaxSubjectLength(n uint) Option {
+	return func(s *commitLintValidator) {
+		s.maxSubjectLength = int(n)
+	}
+}
+
+// WithSubjectPattern sets the regular expression which the subject must match,
+// such as "^(Add|Fix|Remove|Update) " to require the imperative mood.
+func WithSubjectPattern(pattern string) Option {
+	return func(s *commitLintValidator) {
+		if len(pattern) == 0 {
+			return
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			s.optionErrs = append(s.optionErrs, fmt.Errorf("subject pattern is invalid: %w", err))
+			return
+		}
+		s.subjectPattern = re
+	}
+}
+
+// WithNoWorkInProgress sets whether work in progress commits are rejected,
+// such as "WIP" commits and "fixup!", "squash!" or "amend!" commits which are meant to be squashed by autosquash.
+func WithNoWorkInProgress(enabled bool) Option {
+	return func(s *commitLintValidator) {
+		s.noWorkInProgress = enabled
+	}
+}
+
+// WithIssueTrailers sets the trailer keys of which one must reference an issue, such as "Refs: #123".
+// keys is a comma-separated list, such as "Refs,Fixes,Closes", which are matched case-insensitively.
+func WithIssueTrailers(keys string) Option {
+	return func(s *commitLintValidator) {
+		if len(keys) == 0 {
+			return
+		}
+		s.issueTrailers = listutil.Split(keys)
+	}
+}
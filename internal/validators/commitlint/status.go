@@ -0,0 +1,53 @@
+package commitlint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+type invalidCommit struct {
+	title      string
+	violations []string
+}
+
+type status struct {
+	totalCommits   int
+	invalidCommits []*invalidCommit
+	skippedCommits []string // merge commits, which are not validated
+
+	succeeded bool
+}
+
+func (s *status) Detail() string {
+	invalid := make([]string, 0, len(s.invalidCommits))
+	for _, c := range s.invalidCommits {
+		invalid = append(invalid, fmt.Sprintf("%s\n  - %s", c.title, strings.Join(c.violations, "\n  - ")))
+	}
+
+	return fmt.Sprintf(
+		`%d out of %d commits have invalid messages
+
+::group::Invalid commits
+%s
+::endgroup::
+
+::group::Skipped merge commits
+%s
+::endgroup::
+`,
+		len(s.invalidCommits), s.totalCommits,
+		validators.PrettyPrintList(invalid),
+		validators.PrettyPrintList(s.skippedCommits),
+	)
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
+
+// Jobs returns nil, because the validator does not check any job.
+func (s *status) Jobs() []*validators.Job {
+	return nil
+}
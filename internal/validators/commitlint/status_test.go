@@ -0,0 +1,55 @@
+package commitlint
+
+import "testing"
+
+func Test_status_Detail(t *testing.T) {
+	tests := map[string]struct {
+		s    *status
+		want string
+	}{
+		"returns detail with per-commit violations": {
+			s: &status{
+				totalCommits: 3,
+				invalidCommits: []*invalidCommit{
+					{title: "a0b2c3d WIP", violations: []string{"work in progress commit needs to be squashed", `issue reference trailer is missing, such as "Refs: #123"`}},
+					{title: "b0b2c3d added validator", violations: []string{`subject does not match pattern "^[A-Z]"`}},
+				},
+				skippedCommits: []string{"c0b2c3d Merge branch 'main' into feature"},
+			},
+			want: `2 out of 3 commits have invalid messages
+
+::group::Invalid commits
+- a0b2c3d WIP
+  - work in progress commit needs to be squashed
+  - issue reference trailer is missing, such as "Refs: #123"
+- b0b2c3d added validator
+  - subject does not match pattern "^[A-Z]"
+::endgroup::
+
+::group::Skipped merge commits
+- c0b2c3d Merge branch 'main' into feature
+::endgroup::
+`,
+		},
+		"returns detail without invalid commits": {
+			s: &status{totalCommits: 1, succeeded: true},
+			want: `0 out of 1 commits have invalid messages
+
+::group::Invalid commits
+[]
+::endgroup::
+
+::group::Skipped merge commits
+[]
+::endgroup::
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.s.Detail(); got != tt.want {
+				t.Errorf("status.Detail() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package commitlint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "commit-lint"
+
+const (
+	maxItemsPerPage = 100
+
+	// NOTE: GitHub lists up to 250 commits of a pull request.
+	maxCommitPages = 3
+)
+
+type commitLintValidator struct {
+	repo   string
+	owner  string
+	number int
+
+	maxSubjectLength int
+	subjectPattern   *regexp.Regexp
+	noWorkInProgress bool
+	issueTrailers    []string
+
+	client github.Client
+
+	// optionErrs holds errors which occurred while applying options.
+	optionErrs []error
+}
+
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	cv := &commitLintValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(cv)
+	}
+	if err := cv.validateFields(); err != nil {
+		return nil, err
+	}
+	return cv, nil
+}
+
+func (cv *commitLintValidator) Name() string {
+	return validatorName
+}
+
+func (cv *commitLintValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(cv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(cv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if cv.number <= 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if cv.maxSubjectLength == 0 && cv.subjectPattern == nil && !cv.noWorkInProgress && len(cv.issueTrailers) == 0 {
+		errs = append(errs, errors.New("commit message rules are empty"))
+	}
+	if cv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+	errs = append(errs, cv.optionErrs...)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Validate checks the messages of all the commits in the pull request.
+// Unlike other validators, violations fail the validation immediately, because commits only change with a new push,
+// which triggers another validation.
+func (cv *commitLintValidator) Validate(ctx context.Context) (validators.Status, error) {
+	commits, err := cv.listCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &status{
+		totalCommits: len(commits),
+		succeeded:    true,
+	}
+	for _, c := range commits {
+		// Merge commits are generated, such as merging the base branch into the pull request.
+		if len(c.Parents) > 1 {
+			st.skippedCommits = append(st.skippedCommits, commitTitle(c))
+			continue
+		}
+
+		violations := cv.violations(c.GetCommit().GetMessage())
+		if len(violations) == 0 {
+			continue
+		}
+		st.invalidCommits = append(st.invalidCommits, &invalidCommit{
+			title:      commitTitle(c),
+			violations: violations,
+		})
+	}
+
+	if len(st.invalidCommits) != 0 {
+		// The status is returned along with the error, so that the invalid commits can be reported.
+		st.succeeded = false
+		return st, errors.New(st.Detail())
+	}
+
+	return st, nil
+}
+
+// commitTitle returns the short SHA and the subject of the commit, such as "a0b2c3d Add validator".
+func commitTitle(c *github.RepositoryCommit) string {
+	sha := c.GetSHA()
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	return fmt.Sprintf("%s %s", sha, subject(c.GetCommit().GetMessage()))
+}
+
+func (cv *commitLintValidator) listCommits(ctx context.Context) ([]*github.RepositoryCommit, error) {
+	var commits []*github.RepositoryCommit
+	err := github.Paginate(maxCommitPages, "commits", func(page int) (*github.Response, error) {
+		cs, resp, err := cv.client.ListPullRequestCommits(ctx, cv.owner, cv.repo, cv.number, &github.ListOptions{
+			Page:    page,
+			PerPage: maxItemsPerPage,
+		})
+		commits = append(commits, cs...)
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
@@ -0,0 +1,155 @@
+package commitlint
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func commit(sha, message string, parents int) *github.RepositoryCommit {
+	c := &github.RepositoryCommit{
+		SHA:    stringPtr(sha),
+		Commit: &github.Commit{Message: stringPtr(message)},
+	}
+	for i := 0; i < parents; i++ {
+		c.Parents = append(c.Parents, &github.Commit{})
+	}
+	return c
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		wantErr bool
+	}{
+		"returns Validator when options are valid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithMaxSubjectLength(72),
+				WithSubjectPattern("^[A-Z]"),
+				WithNoWorkInProgress(true),
+				WithIssueTrailers("Refs"),
+			},
+			wantErr: false,
+		},
+		"returns error when subject pattern is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithSubjectPattern("(Add"),
+			},
+			wantErr: true,
+		},
+		"returns error when rules are empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+			},
+			wantErr: true,
+		},
+		"returns error when pull request number is empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithNoWorkInProgress(true),
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_commitLintValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		client     github.Client
+		wantErr    bool
+		wantStatus *status
+	}{
+		"returns error when commits cannot be listed": {
+			client: &mock.Client{
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns succeeded status when all commits across pages are valid": {
+			client: &mock.Client{
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					if opts.Page == 1 {
+						return []*github.RepositoryCommit{
+							commit("a0b2c3d4e5f6", "Add validator", 1),
+						}, &github.Response{NextPage: 2}, nil
+					}
+					return []*github.RepositoryCommit{
+						commit("b0b2c3d4e5f6", "Merge branch 'main' into feature", 2),
+					}, &github.Response{}, nil
+				},
+			},
+			wantStatus: &status{
+				totalCommits:   2,
+				skippedCommits: []string{"b0b2c3d Merge branch 'main' into feature"},
+				succeeded:      true,
+			},
+		},
+		"returns failed status and error when commit is invalid": {
+			client: &mock.Client{
+				ListPullRequestCommitsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+					return []*github.RepositoryCommit{
+						commit("a0b2c3d4e5f6", "Add validator", 1),
+						commit("c0b2c3d4e5f6", "WIP", 1),
+					}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantStatus: &status{
+				totalCommits: 2,
+				invalidCommits: []*invalidCommit{
+					{title: "c0b2c3d WIP", violations: []string{"work in progress commit needs to be squashed"}},
+				},
+				succeeded: false,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cv := &commitLintValidator{
+				owner:            "test-owner",
+				repo:             "test-repo",
+				number:           1,
+				noWorkInProgress: true,
+				client:           tt.client,
+			}
+			got, err := cv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("commitLintValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantStatus == nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("commitLintValidator.Validate() status = %+v, want %+v", got, tt.wantStatus)
+			}
+		})
+	}
+}
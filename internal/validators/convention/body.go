@@ -0,0 +1,79 @@
+package convention
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// htmlCommentRegexp matches HTML comments, which are often used for instructions in pull request templates.
+	htmlCommentRegexp = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+	// headingRegexp matches ATX headings of Markdown, such as "## Testing".
+	headingRegexp = regexp.MustCompile(`^ {0,3}#{1,6}[ \t]+(.*?)[ \t#]*$`)
+
+	// checklistItemRegexp matches task list items of Markdown, such as "- [x] Tests are added".
+	checklistItemRegexp = regexp.MustCompile(`^[ \t]*[-*+][ \t]+\[([ xX])\][ \t]+(.*?)[ \t]*$`)
+)
+
+type checklistItem struct {
+	text   string
+	ticked bool
+}
+
+// parseBody returns the headings and checklist items in the Markdown body, ignoring HTML comments.
+func parseBody(body string) ([]string, []checklistItem) {
+	body = htmlCommentRegexp.ReplaceAllString(body, "")
+
+	var headings []string
+	var items []checklistItem
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := headingRegexp.FindStringSubmatch(line); m != nil {
+			headings = append(headings, m[1])
+			continue
+		}
+		if m := checklistItemRegexp.FindStringSubmatch(line); m != nil {
+			items = append(items, checklistItem{text: m[2], ticked: m[1] != " "})
+		}
+	}
+	return headings, items
+}
+
+// bodyViolations returns the violations of the body against the rules.
+func (cv *conventionValidator) bodyViolations(body string) []string {
+	headings, items := parseBody(body)
+
+	var violations []string
+	for _, heading := range cv.requiredHeadings {
+		if !containsFold(headings, heading) {
+			violations = append(violations, fmt.Sprintf("body does not have heading %q", heading))
+		}
+	}
+
+	for _, required := range cv.requiredChecklistItems {
+		var found, ticked bool
+		for _, item := range items {
+			if strings.HasPrefix(strings.ToLower(item.text), strings.ToLower(required)) {
+				found = true
+				ticked = ticked || item.ticked
+			}
+		}
+		switch {
+		case !found:
+			violations = append(violations, fmt.Sprintf("body does not have checklist item %q", required))
+		case !ticked:
+			violations = append(violations, fmt.Sprintf("checklist item %q is not ticked", required))
+		}
+	}
+
+	if cv.allChecklistItemsTicked {
+		for _, item := range items {
+			if !item.ticked {
+				violations = append(violations, fmt.Sprintf("checklist item %q is not ticked", item.text))
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,77 @@
+package convention
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testBody = `## Summary
+
+Add labels validator.
+
+<!--
+## Testing
+- [ ] Commented out item
+-->
+
+### Testing ###
+
+- [x] Unit tests are added
+* [ ] E2E tests are added
+`
+
+func Test_parseBody(t *testing.T) {
+	headings, items := parseBody(testBody)
+
+	wantHeadings := []string{"Summary", "Testing"}
+	if !reflect.DeepEqual(headings, wantHeadings) {
+		t.Errorf("parseBody() headings = %v, want %v", headings, wantHeadings)
+	}
+	wantItems := []checklistItem{
+		{text: "Unit tests are added", ticked: true},
+		{text: "E2E tests are added", ticked: false},
+	}
+	if !reflect.DeepEqual(items, wantItems) {
+		t.Errorf("parseBody() items = %v, want %v", items, wantItems)
+	}
+}
+
+func Test_conventionValidator_bodyViolations(t *testing.T) {
+	tests := map[string]struct {
+		cv   *conventionValidator
+		body string
+		want []string
+	}{
+		"returns nil when required headings and items are present": {
+			cv: &conventionValidator{
+				requiredHeadings:       []string{"summary", "Testing"},
+				requiredChecklistItems: []string{"unit tests"},
+			},
+			body: testBody,
+		},
+		"returns violations when heading and items are missing or not ticked": {
+			cv: &conventionValidator{
+				requiredHeadings:       []string{"Summary", "Release Note"},
+				requiredChecklistItems: []string{"E2E tests", "Docs are updated"},
+			},
+			body: testBody,
+			want: []string{
+				`body does not have heading "Release Note"`,
+				`checklist item "E2E tests" is not ticked`,
+				`body does not have checklist item "Docs are updated"`,
+			},
+		},
+		"returns violations when all items must be ticked": {
+			cv:   &conventionValidator{allChecklistItemsTicked: true},
+			body: testBody,
+			want: []string{`checklist item "E2E tests are added" is not ticked`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.cv.bodyViolations(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bodyViolations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package convention
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/upsidr/merge-gatekeeper/internal/listutil"
+)
+
+type Option func(s *conventionValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(s *conventionValidator) {
+		if len(owner) != 0 {
+			s.owner = owner
+		}
+		if len(repo) != 0 {
+			s.repo = repo
+		}
+	}
+}
+
+func WithPullRequestNumber(number uint) Option {
+	return func(s *conventionValidator) {
+		if number != 0 {
+			s.number = int(number)
+		}
+	}
+}
+
+// WithTitlePattern sets the regular expression which the pull request title must match, such as "^\[[A-Z]+-[0-9]+\] ".
+func WithTitlePattern(pattern string) Option {
+	return func(s *conventionValidator) {
+		if len(pattern) == 0 {
+			return
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			s.optionErrs = append(s.optionErrs, fmt.Errorf("title pattern is invalid: %w", err))
+			return
+		}
+		s.titlePattern = re
+	}
+}
+
+// WithConventionalTitle sets whether the pull request title must follow Conventional Commits,
+// such as "feat(cli): add labels validator".
+// NOTE: https://www.conventionalcommits.org/en/v1.0.0/
+func WithConventionalTitle(enabled bool) Option {
+	return func(s *conventionValidator) {
+		s.conventionalTitle = enabled
+	}
+}
+
+// WithTitleTypes sets the types allowed in the Conventional Commits title.
+// types is a comma-separated list, such as "feat,fix". The common types are allowed by default.
+func WithTitleTypes(types string) Option {
+	return func(s *conventionValidator) {
+		if len(types) == 0 {
+			return
+		}
+		s.titleTypes = listutil.Split(types)
+	}
+}
+
+// WithTitleScopes sets the scopes allowed in the Conventional Commits title.
+// scopes is a comma-separated list, such as "cli,github". Any scope is allowed by default, and the scope is always optional.
+func WithTitleScopes(scopes string) Option {
+	return func(s *conventionValidator) {
+		if len(scopes) == 0 {
+			return
+		}
+		s.titleScopes = listutil.Split(scopes)
+	}
+}
+
+// WithBreakingChangeMarker sets whether the breaking change marker "!" is allowed in the Conventional Commits title,
+// such as "feat!: drop support of v1".
+func WithBreakingChangeMarker(allowed bool) Option {
+	return func(s *conventionValidator) {
+		s.breakingChangeAllowed = allowed
+	}
+}
+
+// WithRequiredHeadings sets the Markdown headings which must be in the pull request body.
+// headings is a comma-separated list of heading texts, such as "Summary,Testing", which are matched case-insensitively.
+func WithRequiredHeadings(headings string) Option {
+	return func(s *conventionValidator) {
+		if len(headings) == 0 {
+			return
+		}
+		s.requiredHeadings = listutil.Split(headings)
+	}
+}
+
+// WithRequiredChecklistItems sets the checklist items which must be ticked in the pull request body.
+// items is a comma-separated list of the beginnings of item texts, such as "Tests are added", which are matched case-insensitively.
+func WithRequiredChecklistItems(items string) Option {
+	return func(s *conventionValidator) {
+		if len(items) == 0 {
+			return
+		}
+		s.requiredChecklistItems = listutil.Split(items)
+	}
+}
+
+// WithAllChecklistItemsTicked sets whether every checklist item in the pull request body must be ticked.
+func WithAllChecklistItemsTicked(required bool) Option {
+	return func(s *conventionValidator) {
+		s.allChecklistItemsTicked = required
+	}
+}
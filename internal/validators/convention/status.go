@@ -0,0 +1,39 @@
+package convention
+
+import (
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+type status struct {
+	title      string
+	violations []string
+
+	succeeded bool
+}
+
+func (s *status) Detail() string {
+	return fmt.Sprintf(
+		`%d pull request convention violations
+
+Title: %s
+
+::group::Violations
+%s
+::endgroup::
+`,
+		len(s.violations),
+		s.title,
+		validators.PrettyPrintList(s.violations),
+	)
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
+
+// Jobs returns nil, because the validator does not check any job.
+func (s *status) Jobs() []*validators.Job {
+	return nil
+}
@@ -0,0 +1,43 @@
+package convention
+
+import "testing"
+
+func Test_status_Detail(t *testing.T) {
+	tests := map[string]struct {
+		s    *status
+		want string
+	}{
+		"returns detail with violations": {
+			s: &status{
+				title:      "Update usage",
+				violations: []string{`body does not have heading "Testing"`},
+			},
+			want: `1 pull request convention violations
+
+Title: Update usage
+
+::group::Violations
+- body does not have heading "Testing"
+::endgroup::
+`,
+		},
+		"returns detail without violations": {
+			s: &status{title: "docs: update usage", succeeded: true},
+			want: `0 pull request convention violations
+
+Title: docs: update usage
+
+::group::Violations
+[]
+::endgroup::
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.s.Detail(); got != tt.want {
+				t.Errorf("status.Detail() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
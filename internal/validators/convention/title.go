@@ -0,0 +1,87 @@
+package convention
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultTitleTypes are the types allowed in the Conventional Commits title by default,
+// which follow the conventional changelog of Angular.
+var defaultTitleTypes = []string{
+	"build",
+	"chore",
+	"ci",
+	"docs",
+	"feat",
+	"fix",
+	"perf",
+	"refactor",
+	"revert",
+	"style",
+	"test",
+}
+
+// conventionalTitleRegexp matches "type(scope)!: description", where the scope and "!" are optional.
+var conventionalTitleRegexp = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()\s]+)\))?(!)?: (\S.*)$`)
+
+// conventionalTitle is the parsed title of Conventional Commits.
+type conventionalTitle struct {
+	typ         string
+	scope       string
+	breaking    bool
+	description string
+}
+
+func parseConventionalTitle(title string) (*conventionalTitle, error) {
+	m := conventionalTitleRegexp.FindStringSubmatch(strings.TrimSpace(title))
+	if m == nil {
+		return nil, fmt.Errorf("title %q does not follow Conventional Commits, such as \"feat(scope): description\"", title)
+	}
+	return &conventionalTitle{
+		typ:         m[1],
+		scope:       m[2],
+		breaking:    m[3] == "!",
+		description: m[4],
+	}, nil
+}
+
+// titleViolations returns the violations of the title against the rules.
+func (cv *conventionValidator) titleViolations(title string) []string {
+	var violations []string
+	if cv.titlePattern != nil && !cv.titlePattern.MatchString(title) {
+		violations = append(violations, fmt.Sprintf("title does not match pattern %q", cv.titlePattern))
+	}
+	if !cv.conventionalTitle {
+		return violations
+	}
+
+	ct, err := parseConventionalTitle(title)
+	if err != nil {
+		return append(violations, err.Error())
+	}
+
+	types := cv.titleTypes
+	if len(types) == 0 {
+		types = defaultTitleTypes
+	}
+	if !containsFold(types, ct.typ) {
+		violations = append(violations, fmt.Sprintf("title type %q is not one of %s", ct.typ, strings.Join(types, ", ")))
+	}
+	if len(ct.scope) != 0 && len(cv.titleScopes) != 0 && !containsFold(cv.titleScopes, ct.scope) {
+		violations = append(violations, fmt.Sprintf("title scope %q is not one of %s", ct.scope, strings.Join(cv.titleScopes, ", ")))
+	}
+	if ct.breaking && !cv.breakingChangeAllowed {
+		violations = append(violations, "title has breaking change marker \"!\", which is not allowed")
+	}
+	return violations
+}
+
+func containsFold(list []string, s string) bool {
+	for _, l := range list {
+		if strings.EqualFold(l, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package convention
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func Test_parseConventionalTitle(t *testing.T) {
+	tests := map[string]struct {
+		title   string
+		want    *conventionalTitle
+		wantErr bool
+	}{
+		"returns title with type and description": {
+			title: "fix: handle empty response",
+			want:  &conventionalTitle{typ: "fix", description: "handle empty response"},
+		},
+		"returns title with scope and breaking change marker": {
+			title: "feat(cli)!: drop token flag",
+			want:  &conventionalTitle{typ: "feat", scope: "cli", breaking: true, description: "drop token flag"},
+		},
+		"returns error when type is missing": {
+			title:   "handle empty response",
+			wantErr: true,
+		},
+		"returns error when scope is empty": {
+			title:   "fix(): handle empty response",
+			wantErr: true,
+		},
+		"returns error when space after colon is missing": {
+			title:   "fix:handle empty response",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseConventionalTitle(tt.title)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseConventionalTitle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseConventionalTitle() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_conventionValidator_titleViolations(t *testing.T) {
+	tests := map[string]struct {
+		cv    *conventionValidator
+		title string
+		want  []string
+	}{
+		"returns nil when title matches pattern": {
+			cv:    &conventionValidator{titlePattern: regexp.MustCompile(`^\[[A-Z]+-[0-9]+\] `)},
+			title: "[ABC-123] Fix bug",
+		},
+		"returns violation when title does not match pattern": {
+			cv:    &conventionValidator{titlePattern: regexp.MustCompile(`^\[[A-Z]+-[0-9]+\] `)},
+			title: "Fix bug",
+			want:  []string{`title does not match pattern "^\\[[A-Z]+-[0-9]+\\] "`},
+		},
+		"returns nil when conventional title has default type": {
+			cv:    &conventionValidator{conventionalTitle: true, breakingChangeAllowed: true},
+			title: "feat!: drop token flag",
+		},
+		"returns violations when type, scope and breaking change are not allowed": {
+			cv: &conventionValidator{
+				conventionalTitle: true,
+				titleTypes:        []string{"feat", "fix"},
+				titleScopes:       []string{"cli"},
+			},
+			title: "docs(github)!: update usage",
+			want: []string{
+				`title type "docs" is not one of feat, fix`,
+				`title scope "github" is not one of cli`,
+				`title has breaking change marker "!", which is not allowed`,
+			},
+		},
+		"returns violation when title does not follow conventional commits": {
+			cv:    &conventionValidator{conventionalTitle: true},
+			title: "Update usage",
+			want:  []string{`title "Update usage" does not follow Conventional Commits, such as "feat(scope): description"`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.cv.titleViolations(tt.title); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titleViolations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
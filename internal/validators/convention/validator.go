@@ -0,0 +1,102 @@
+package convention
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "pull-request-convention"
+
+var ErrInvalidPullRequestResponse = errors.New("github pull request response is invalid")
+
+type conventionValidator struct {
+	repo   string
+	owner  string
+	number int
+
+	titlePattern          *regexp.Regexp
+	conventionalTitle     bool
+	titleTypes            []string
+	titleScopes           []string
+	breakingChangeAllowed bool
+
+	requiredHeadings        []string
+	requiredChecklistItems  []string
+	allChecklistItemsTicked bool
+
+	client github.Client
+
+	// optionErrs holds errors which occurred while applying options.
+	optionErrs []error
+}
+
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	cv := &conventionValidator{
+		breakingChangeAllowed: true,
+		client:                c,
+	}
+	for _, opt := range opts {
+		opt(cv)
+	}
+	if err := cv.validateFields(); err != nil {
+		return nil, err
+	}
+	return cv, nil
+}
+
+func (cv *conventionValidator) Name() string {
+	return validatorName
+}
+
+func (cv *conventionValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(cv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(cv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if cv.number <= 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if !cv.conventionalTitle && (len(cv.titleTypes) != 0 || len(cv.titleScopes) != 0) {
+		errs = append(errs, errors.New("title types and scopes require Conventional Commits title"))
+	}
+	if cv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+	errs = append(errs, cv.optionErrs...)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Validate checks the title and body of the pull request on every call, so that editing them during the validation
+// is reflected in the next check. Violations keep the validation pending rather than failing it.
+func (cv *conventionValidator) Validate(ctx context.Context) (validators.Status, error) {
+	pr, _, err := cv.client.GetPullRequest(ctx, cv.owner, cv.repo, cv.number)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrInvalidPullRequestResponse
+	}
+
+	st := &status{
+		title: pr.GetTitle(),
+	}
+	st.violations = append(st.violations, cv.titleViolations(pr.GetTitle())...)
+	st.violations = append(st.violations, cv.bodyViolations(pr.GetBody())...)
+	st.succeeded = len(st.violations) == 0
+
+	return st, nil
+}
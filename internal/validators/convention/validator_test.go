@@ -0,0 +1,143 @@
+package convention
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		wantErr bool
+	}{
+		"returns Validator when options are valid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithConventionalTitle(true),
+				WithTitleTypes("feat,fix"),
+				WithRequiredHeadings("Testing"),
+			},
+			wantErr: false,
+		},
+		"returns error when title pattern is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithTitlePattern("(feat"),
+			},
+			wantErr: true,
+		},
+		"returns error when title types are set without conventional title": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithTitleTypes("feat,fix"),
+			},
+			wantErr: true,
+		},
+		"returns error when pull request number is empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithConventionalTitle(true),
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_conventionValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		client     github.Client
+		wantErr    bool
+		wantStatus *status
+	}{
+		"returns error when pull request cannot be fetched": {
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns succeeded status when title and body follow the conventions": {
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{
+						Title: stringPtr("feat(cli): add labels validator"),
+						Body:  stringPtr("## Testing\n\n- [x] Unit tests are added\n"),
+					}, nil, nil
+				},
+			},
+			wantStatus: &status{
+				title:     "feat(cli): add labels validator",
+				succeeded: true,
+			},
+		},
+		"returns pending status with violations of title and body": {
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{
+						Title: stringPtr("wip: add labels validator"),
+						Body:  stringPtr("- [ ] Unit tests are added\n"),
+					}, nil, nil
+				},
+			},
+			wantStatus: &status{
+				title: "wip: add labels validator",
+				violations: []string{
+					`title type "wip" is not one of build, chore, ci, docs, feat, fix, perf, refactor, revert, style, test`,
+					`body does not have heading "Testing"`,
+					`checklist item "Unit tests are added" is not ticked`,
+				},
+				succeeded: false,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cv := &conventionValidator{
+				owner:                   "test-owner",
+				repo:                    "test-repo",
+				number:                  1,
+				conventionalTitle:       true,
+				breakingChangeAllowed:   true,
+				requiredHeadings:        []string{"Testing"},
+				allChecklistItemsTicked: true,
+				client:                  tt.client,
+			}
+			got, err := cv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("conventionValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("conventionValidator.Validate() status = %+v, want %+v", got, tt.wantStatus)
+			}
+		})
+	}
+}
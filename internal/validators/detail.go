@@ -0,0 +1,19 @@
+package validators
+
+import "fmt"
+
+// PrettyPrintList formats the items as a Markdown list for Status.Detail, or "[]" when there is no item.
+func PrettyPrintList(items []string) string {
+	result := ""
+	if len(items) == 0 {
+		result = "[]"
+	}
+	for i, item := range items {
+		result += fmt.Sprintf("- %s", item)
+		if i != len(items)-1 {
+			result += "\n"
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,57 @@
+package labels
+
+import "github.com/upsidr/merge-gatekeeper/internal/listutil"
+
+type Option func(s *labelsValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(s *labelsValidator) {
+		if len(owner) != 0 {
+			s.owner = owner
+		}
+		if len(repo) != 0 {
+			s.repo = repo
+		}
+	}
+}
+
+func WithPullRequestNumber(number uint) Option {
+	return func(s *labelsValidator) {
+		if number != 0 {
+			s.number = int(number)
+		}
+	}
+}
+
+// WithRequiredLabels sets the labels which must all be present on the pull request.
+// labels is a comma-separated list of label names, such as "ready-to-merge".
+func WithRequiredLabels(labels string) Option {
+	return func(s *labelsValidator) {
+		if len(labels) == 0 {
+			return
+		}
+		s.requiredLabels = listutil.Split(labels)
+	}
+}
+
+// WithForbiddenLabels sets the labels which block the merge while any of them is present on the pull request.
+// labels is a comma-separated list of label names, such as "do-not-merge,needs-qa".
+func WithForbiddenLabels(labels string) Option {
+	return func(s *labelsValidator) {
+		if len(labels) == 0 {
+			return
+		}
+		s.forbiddenLabels = listutil.Split(labels)
+	}
+}
+
+// WithOneOfLabels sets the labels of which exactly one must be present on the pull request.
+// labels is a comma-separated list of label names, such as "major,minor,patch".
+func WithOneOfLabels(labels string) Option {
+	return func(s *labelsValidator) {
+		if len(labels) == 0 {
+			return
+		}
+		s.oneOfLabels = listutil.Split(labels)
+	}
+}
@@ -0,0 +1,41 @@
+package labels
+
+import (
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+type status struct {
+	labels     []string
+	violations []string
+
+	succeeded bool
+}
+
+func (s *status) Detail() string {
+	return fmt.Sprintf(
+		`%d label rule violations
+
+::group::Violations
+%s
+::endgroup::
+
+::group::Labels
+%s
+::endgroup::
+`,
+		len(s.violations),
+		validators.PrettyPrintList(s.violations),
+		validators.PrettyPrintList(s.labels),
+	)
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
+
+// Jobs returns nil, because the validator does not check any job.
+func (s *status) Jobs() []*validators.Job {
+	return nil
+}
@@ -0,0 +1,47 @@
+package labels
+
+import "testing"
+
+func Test_status_Detail(t *testing.T) {
+	tests := map[string]struct {
+		s    *status
+		want string
+	}{
+		"returns detail with violations and labels": {
+			s: &status{
+				labels:     []string{"do-not-merge"},
+				violations: []string{`forbidden label "do-not-merge" is present`},
+			},
+			want: `1 label rule violations
+
+::group::Violations
+- forbidden label "do-not-merge" is present
+::endgroup::
+
+::group::Labels
+- do-not-merge
+::endgroup::
+`,
+		},
+		"returns detail without labels": {
+			s: &status{succeeded: true},
+			want: `0 label rule violations
+
+::group::Violations
+[]
+::endgroup::
+
+::group::Labels
+[]
+::endgroup::
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.s.Detail(); got != tt.want {
+				t.Errorf("status.Detail() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
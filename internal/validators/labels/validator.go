@@ -0,0 +1,141 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "labels"
+
+var ErrInvalidPullRequestResponse = errors.New("github pull request response is invalid")
+
+type labelsValidator struct {
+	repo            string
+	owner           string
+	number          int
+	requiredLabels  []string
+	forbiddenLabels []string
+	oneOfLabels     []string
+	client          github.Client
+}
+
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	lv := &labelsValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(lv)
+	}
+	if err := lv.validateFields(); err != nil {
+		return nil, err
+	}
+	return lv, nil
+}
+
+func (lv *labelsValidator) Name() string {
+	return validatorName
+}
+
+func (lv *labelsValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(lv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(lv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if lv.number <= 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if len(lv.requiredLabels) == 0 && len(lv.forbiddenLabels) == 0 && len(lv.oneOfLabels) == 0 {
+		errs = append(errs, errors.New("label rules are empty"))
+	}
+	for _, label := range lv.requiredLabels {
+		if containsLabel(lv.forbiddenLabels, label) {
+			errs = append(errs, fmt.Errorf("label %q is both required and forbidden", label))
+		}
+	}
+	if lv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Validate checks the labels of the pull request on every call, so that adding or removing a label
+// during the validation is reflected in the next check.
+// Violations keep the validation pending rather than failing it, because labels are expected to change.
+func (lv *labelsValidator) Validate(ctx context.Context) (validators.Status, error) {
+	pr, _, err := lv.client.GetPullRequest(ctx, lv.owner, lv.repo, lv.number)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrInvalidPullRequestResponse
+	}
+
+	st := &status{
+		labels: make([]string, 0, len(pr.Labels)),
+	}
+	for _, l := range pr.Labels {
+		st.labels = append(st.labels, l.GetName())
+	}
+
+	for _, label := range lv.requiredLabels {
+		if !containsLabel(st.labels, label) {
+			st.violations = append(st.violations, fmt.Sprintf("required label %q is missing", label))
+		}
+	}
+	for _, label := range lv.forbiddenLabels {
+		if containsLabel(st.labels, label) {
+			st.violations = append(st.violations, fmt.Sprintf("forbidden label %q is present", label))
+		}
+	}
+	if len(lv.oneOfLabels) != 0 {
+		var present []string
+		for _, label := range lv.oneOfLabels {
+			if containsLabel(st.labels, label) {
+				present = append(present, label)
+			}
+		}
+		switch len(present) {
+		case 0:
+			st.violations = append(st.violations, fmt.Sprintf("one of labels %s is required, but none is present", quoteLabels(lv.oneOfLabels)))
+		case 1:
+		default:
+			st.violations = append(st.violations, fmt.Sprintf("only one of labels %s is allowed, but %s are present", quoteLabels(lv.oneOfLabels), quoteLabels(present)))
+		}
+	}
+	st.succeeded = len(st.violations) == 0
+
+	return st, nil
+}
+
+// containsLabel reports whether the label is in the labels, ignoring case as GitHub does.
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteLabels(labels []string) string {
+	quoted := make([]string, 0, len(labels))
+	for _, l := range labels {
+		quoted = append(quoted, fmt.Sprintf("%q", l))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,172 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func pullRequest(labels ...string) func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+		pr := &github.PullRequest{}
+		for _, l := range labels {
+			pr.Labels = append(pr.Labels, &github.Label{Name: stringPtr(l)})
+		}
+		return pr, nil, nil
+	}
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		wantErr bool
+	}{
+		"returns Validator when options are valid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithRequiredLabels("ready-to-merge"),
+				WithForbiddenLabels("do-not-merge, needs-qa"),
+				WithOneOfLabels("major,minor,patch"),
+			},
+			wantErr: false,
+		},
+		"returns error when label rules are empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+			},
+			wantErr: true,
+		},
+		"returns error when label is both required and forbidden": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithRequiredLabels("needs-qa"),
+				WithForbiddenLabels("Needs-QA"),
+			},
+			wantErr: true,
+		},
+		"returns error when pull request number is empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithRequiredLabels("ready-to-merge"),
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_labelsValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		requiredLabels  []string
+		forbiddenLabels []string
+		oneOfLabels     []string
+		client          github.Client
+		wantErr         bool
+		wantStatus      *status
+	}{
+		"returns error when pull request cannot be fetched": {
+			requiredLabels: []string{"ready-to-merge"},
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns succeeded status when all rules are satisfied": {
+			requiredLabels:  []string{"ready-to-merge"},
+			forbiddenLabels: []string{"do-not-merge", "needs-qa"},
+			oneOfLabels:     []string{"major", "minor", "patch"},
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("Ready-To-Merge", "minor"),
+			},
+			wantStatus: &status{
+				labels:    []string{"Ready-To-Merge", "minor"},
+				succeeded: true,
+			},
+		},
+		"returns pending status when required label is missing and forbidden label is present": {
+			requiredLabels:  []string{"ready-to-merge"},
+			forbiddenLabels: []string{"do-not-merge", "needs-qa"},
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("do-not-merge"),
+			},
+			wantStatus: &status{
+				labels: []string{"do-not-merge"},
+				violations: []string{
+					`required label "ready-to-merge" is missing`,
+					`forbidden label "do-not-merge" is present`,
+				},
+				succeeded: false,
+			},
+		},
+		"returns pending status when none of one-of labels is present": {
+			oneOfLabels: []string{"major", "minor", "patch"},
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest(),
+			},
+			wantStatus: &status{
+				labels:     []string{},
+				violations: []string{`one of labels "major", "minor", "patch" is required, but none is present`},
+				succeeded:  false,
+			},
+		},
+		"returns pending status when more than one of one-of labels are present": {
+			oneOfLabels: []string{"major", "minor", "patch"},
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("major", "patch"),
+			},
+			wantStatus: &status{
+				labels:     []string{"major", "patch"},
+				violations: []string{`only one of labels "major", "minor", "patch" is allowed, but "major", "patch" are present`},
+				succeeded:  false,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			lv := &labelsValidator{
+				owner:           "test-owner",
+				repo:            "test-repo",
+				number:          1,
+				requiredLabels:  tt.requiredLabels,
+				forbiddenLabels: tt.forbiddenLabels,
+				oneOfLabels:     tt.oneOfLabels,
+				client:          tt.client,
+			}
+			got, err := lv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("labelsValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("labelsValidator.Validate() status = %+v, want %+v", got, tt.wantStatus)
+			}
+		})
+	}
+}
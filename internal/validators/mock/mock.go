@@ -9,6 +9,7 @@ import (
 type Status struct {
 	DetailFunc    func() string
 	IsSuccessFunc func() bool
+	JobsFunc      func() []*validators.Job
 }
 
 func (s *Status) Detail() string {
@@ -19,6 +20,10 @@ func (s *Status) IsSuccess() bool {
 	return s.IsSuccessFunc()
 }
 
+func (s *Status) Jobs() []*validators.Job {
+	return s.JobsFunc()
+}
+
 type Validator struct {
 	NameFunc     func() string
 	ValidateFunc func(ctx context.Context) (validators.Status, error)
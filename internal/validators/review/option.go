@@ -0,0 +1,37 @@
+package review
+
+type Option func(s *reviewValidator)
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(s *reviewValidator) {
+		if len(owner) != 0 {
+			s.owner = owner
+		}
+		if len(repo) != 0 {
+			s.repo = repo
+		}
+	}
+}
+
+func WithPullRequestNumber(number uint) Option {
+	return func(s *reviewValidator) {
+		if number != 0 {
+			s.number = int(number)
+		}
+	}
+}
+
+// WithMinimumApprovals sets the number of reviewers who must approve the pull request.
+func WithMinimumApprovals(n uint) Option {
+	return func(s *reviewValidator) {
+		s.minApprovals = int(n)
+	}
+}
+
+// WithApprovalAfterLastPush sets whether only approvals of the latest commit are counted,
+// so that approvals become stale when new commits are pushed.
+func WithApprovalAfterLastPush(required bool) Option {
+	return func(s *reviewValidator) {
+		s.approvalAfterLastPush = required
+	}
+}
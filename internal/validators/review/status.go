@@ -0,0 +1,70 @@
+package review
+
+import (
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+type status struct {
+	minApprovals     int
+	approvals        []string
+	changesRequested []string
+	dismissed        []string
+	// staleApprovals holds approvals of older commits, which are not counted when approvals after the last push are required.
+	staleApprovals []string
+
+	succeeded bool
+}
+
+func (s *status) Detail() string {
+	result := fmt.Sprintf(
+		`%d out of %d required approvals
+
+Approval count:          %d
+Changes requested count: %d
+Dismissed review count:  %d
+
+::group::Approved by
+%s
+::endgroup::
+
+::group::Changes requested by
+%s
+::endgroup::
+
+::group::Dismissed reviews
+%s
+::endgroup::
+`,
+		len(s.approvals), s.minApprovals,
+		len(s.approvals),
+		len(s.changesRequested),
+		len(s.dismissed),
+		validators.PrettyPrintList(s.approvals),
+		validators.PrettyPrintList(s.changesRequested),
+		validators.PrettyPrintList(s.dismissed),
+	)
+
+	if len(s.staleApprovals) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Approvals before the last push
+%s
+::endgroup::
+`,
+			result,
+			validators.PrettyPrintList(s.staleApprovals),
+		)
+	}
+
+	return result
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
+
+// Jobs returns nil, because the validator does not check any job.
+func (s *status) Jobs() []*validators.Job {
+	return nil
+}
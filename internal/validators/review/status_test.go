@@ -0,0 +1,71 @@
+package review
+
+import "testing"
+
+func Test_status_Detail(t *testing.T) {
+	tests := map[string]struct {
+		s    *status
+		want string
+	}{
+		"returns detail with approvals and change requests": {
+			s: &status{
+				minApprovals:     2,
+				approvals:        []string{"alice"},
+				changesRequested: []string{"bob"},
+			},
+			want: `1 out of 2 required approvals
+
+Approval count:          1
+Changes requested count: 1
+Dismissed review count:  0
+
+::group::Approved by
+- alice
+::endgroup::
+
+::group::Changes requested by
+- bob
+::endgroup::
+
+::group::Dismissed reviews
+[]
+::endgroup::
+`,
+		},
+		"returns detail with stale approvals": {
+			s: &status{
+				minApprovals:   1,
+				staleApprovals: []string{"alice (approved a0b2c3d)"},
+			},
+			want: `0 out of 1 required approvals
+
+Approval count:          0
+Changes requested count: 0
+Dismissed review count:  0
+
+::group::Approved by
+[]
+::endgroup::
+
+::group::Changes requested by
+[]
+::endgroup::
+
+::group::Dismissed reviews
+[]
+::endgroup::
+
+::group::Approvals before the last push
+- alice (approved a0b2c3d)
+::endgroup::
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.s.Detail(); got != tt.want {
+				t.Errorf("status.Detail() didn't match\n  got:\n%s\n\n  want:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const validatorName = "pull-request-review"
+
+var ErrInvalidPullRequestResponse = errors.New("github pull request response is invalid")
+
+type reviewValidator struct {
+	repo                  string
+	owner                 string
+	number                int
+	minApprovals          int
+	approvalAfterLastPush bool
+	client                github.Client
+}
+
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	rv := &reviewValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(rv)
+	}
+	if err := rv.validateFields(); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (rv *reviewValidator) Name() string {
+	return validatorName
+}
+
+func (rv *reviewValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 5)
+
+	if len(rv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(rv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if rv.number <= 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if rv.minApprovals < 0 {
+		errs = append(errs, errors.New("minimum approval count is negative"))
+	}
+	if rv.client == nil {
+		errs = append(errs, errors.New("github client is empty"))
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (rv *reviewValidator) Validate(ctx context.Context) (validators.Status, error) {
+	pr, _, err := rv.client.GetPullRequest(ctx, rv.owner, rv.repo, rv.number)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil || pr.GetHead().GetSHA() == "" {
+		return nil, ErrInvalidPullRequestResponse
+	}
+	headSHA := pr.GetHead().GetSHA()
+	author := pr.GetUser().GetLogin()
+
+	reviews, err := github.ListReviews(ctx, rv.client, rv.owner, rv.repo, rv.number)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &status{
+		minApprovals: rv.minApprovals,
+	}
+	for _, r := range github.LatestReviews(reviews, author) {
+		login := r.GetUser().GetLogin()
+		switch r.GetState() {
+		case github.ReviewStateApproved:
+			if rv.approvalAfterLastPush && r.GetCommitID() != headSHA {
+				st.staleApprovals = append(st.staleApprovals, fmt.Sprintf("%s (approved %s)", login, shortSHA(r.GetCommitID())))
+				continue
+			}
+			st.approvals = append(st.approvals, login)
+		case github.ReviewStateChangesRequested:
+			st.changesRequested = append(st.changesRequested, login)
+		case github.ReviewStateDismissed:
+			st.dismissed = append(st.dismissed, login)
+		}
+	}
+	st.succeeded = len(st.approvals) >= rv.minApprovals && len(st.changesRequested) == 0
+
+	return st, nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,218 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func review(login, state, commitID string) *github.PullRequestReview {
+	return &github.PullRequestReview{
+		User:     &github.User{Login: stringPtr(login)},
+		State:    stringPtr(state),
+		CommitID: stringPtr(commitID),
+	}
+}
+
+func pullRequest(author, headSHA string) func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+		return &github.PullRequest{
+			User: &github.User{Login: stringPtr(author)},
+			Head: &github.PullRequestBranch{SHA: stringPtr(headSHA)},
+		}, nil, nil
+	}
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		wantErr bool
+	}{
+		"returns Validator when options are valid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+				WithMinimumApprovals(2),
+				WithApprovalAfterLastPush(true),
+			},
+			wantErr: false,
+		},
+		"returns error when pull request number is empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithMinimumApprovals(2),
+			},
+			wantErr: true,
+		},
+		"returns error when client is nil": {
+			c: nil,
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequestNumber(1),
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_reviewValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		minApprovals          int
+		approvalAfterLastPush bool
+		client                github.Client
+		wantErr               bool
+		wantStatus            *status
+	}{
+		"returns error when pull request cannot be fetched": {
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns error when reviews cannot be listed": {
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("author", "sha-2"),
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns succeeded status when enough reviewers approved": {
+			minApprovals: 2,
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("author", "sha-2"),
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return []*github.PullRequestReview{
+						review("alice", github.ReviewStateApproved, "sha-1"),
+						review("bob", "COMMENTED", "sha-1"),
+						review("bob", github.ReviewStateApproved, "sha-2"),
+						review("alice", "COMMENTED", "sha-2"), // Comments do not override the approval
+						review("author", github.ReviewStateApproved, "sha-2"),
+					}, nil, nil
+				},
+			},
+			wantStatus: &status{
+				minApprovals: 2,
+				approvals:    []string{"alice", "bob"},
+				succeeded:    true,
+			},
+		},
+		"returns pending status when the latest review of a reviewer requests changes": {
+			minApprovals: 1,
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("author", "sha-2"),
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return []*github.PullRequestReview{
+						review("alice", github.ReviewStateApproved, "sha-1"),
+						review("bob", github.ReviewStateApproved, "sha-1"),
+						review("bob", github.ReviewStateChangesRequested, "sha-2"),
+					}, nil, nil
+				},
+			},
+			wantStatus: &status{
+				minApprovals:     1,
+				approvals:        []string{"alice"},
+				changesRequested: []string{"bob"},
+				succeeded:        false,
+			},
+		},
+		"returns pending status when approval was dismissed": {
+			minApprovals: 1,
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("author", "sha-2"),
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return []*github.PullRequestReview{
+						review("alice", github.ReviewStateDismissed, "sha-1"),
+					}, nil, nil
+				},
+			},
+			wantStatus: &status{
+				minApprovals: 1,
+				dismissed:    []string{"alice"},
+				succeeded:    false,
+			},
+		},
+		"returns pending status when approvals are before the last push": {
+			minApprovals:          1,
+			approvalAfterLastPush: true,
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("author", "b0b2c3d4e5f6"),
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return []*github.PullRequestReview{
+						review("alice", github.ReviewStateApproved, "a0b2c3d4e5f6"),
+					}, nil, nil
+				},
+			},
+			wantStatus: &status{
+				minApprovals:   1,
+				staleApprovals: []string{"alice (approved a0b2c3d)"},
+				succeeded:      false,
+			},
+		},
+		"returns succeeded status with reviews across pages": {
+			minApprovals: 2,
+			client: &mock.Client{
+				GetPullRequestFunc: pullRequest("author", "sha-2"),
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					if opts.Page == 1 {
+						return []*github.PullRequestReview{
+							review("alice", github.ReviewStateApproved, "sha-2"),
+						}, &github.Response{NextPage: 2}, nil
+					}
+					return []*github.PullRequestReview{
+						review("bob", github.ReviewStateApproved, "sha-2"),
+					}, &github.Response{}, nil
+				},
+			},
+			wantStatus: &status{
+				minApprovals: 2,
+				approvals:    []string{"alice", "bob"},
+				succeeded:    true,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rv := &reviewValidator{
+				owner:                 "test-owner",
+				repo:                  "test-repo",
+				number:                1,
+				minApprovals:          tt.minApprovals,
+				approvalAfterLastPush: tt.approvalAfterLastPush,
+				client:                tt.client,
+			}
+			got, err := rv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reviewValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("reviewValidator.Validate() status = %+v, want %+v", got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+)
+
+// conclusionPolicy decides how a completed check run is treated based on its conclusion.
+type conclusionPolicy string
+
+const (
+	successPolicy conclusionPolicy = "success"
+	failurePolicy conclusionPolicy = "failure"
+	pendingPolicy conclusionPolicy = "pending"
+	ignorePolicy  conclusionPolicy = "ignore"
+)
+
+// NOTE: https://docs.github.com/en/rest/reference/checks
+var checkRunConclusions = map[string]struct{}{
+	"action_required":         {},
+	"cancelled":               {},
+	"failure":                 {},
+	checkRunNeutralConclusion: {},
+	checkRunSuccessConclusion: {},
+	checkRunSkipConclusion:    {},
+	"stale":                   {},
+	"startup_failure":         {},
+	"timed_out":               {},
+}
+
+// defaultConclusionPolicies is applied when no policy is set for a conclusion.
+// Any conclusion not listed here is treated as failure.
+var defaultConclusionPolicies = map[string]conclusionPolicy{
+	checkRunSuccessConclusion: successPolicy,
+	checkRunNeutralConclusion: successPolicy,
+	checkRunSkipConclusion:    ignorePolicy,
+}
+
+// parseConclusionPolicies parses a comma-separated list of "conclusion=policy" pairs, such as "cancelled=pending,neutral=failure".
+func parseConclusionPolicies(str string) (map[string]conclusionPolicy, error) {
+	policies := make(map[string]conclusionPolicy)
+	var errs multierror.Errors
+	for _, s := range strings.Split(str, ",") {
+		pair := strings.TrimSpace(s)
+		if len(pair) == 0 {
+			continue
+		}
+
+		sp := strings.SplitN(pair, "=", 2)
+		if len(sp) != 2 {
+			errs = append(errs, fmt.Errorf("conclusion policy %q must be in the form of conclusion=policy", pair))
+			continue
+		}
+		conclusion := strings.ToLower(strings.TrimSpace(sp[0]))
+		policy := conclusionPolicy(strings.ToLower(strings.TrimSpace(sp[1])))
+
+		if _, ok := checkRunConclusions[conclusion]; !ok {
+			errs = append(errs, fmt.Errorf("conclusion %q is unknown", conclusion))
+			continue
+		}
+		switch policy {
+		case successPolicy, failurePolicy, pendingPolicy, ignorePolicy:
+		default:
+			errs = append(errs, fmt.Errorf("policy %q for conclusion %q is unknown, it must be one of success, failure, pending or ignore", policy, conclusion))
+			continue
+		}
+		policies[conclusion] = policy
+	}
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	return policies, nil
+}
+
+func (sv *statusValidator) conclusionPolicy(conclusion string) conclusionPolicy {
+	if p, ok := sv.conclusionPolicies[conclusion]; ok {
+		return p
+	}
+	if p, ok := defaultConclusionPolicies[conclusion]; ok {
+		return p
+	}
+	return failurePolicy
+}
@@ -0,0 +1,94 @@
+package status
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseConclusionPolicies(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    map[string]conclusionPolicy
+		wantErr bool
+	}{
+		"returns policies from comma-separated list": {
+			str: "cancelled=pending, neutral = failure,Stale=ignore",
+			want: map[string]conclusionPolicy{
+				"cancelled": pendingPolicy,
+				"neutral":   failurePolicy,
+				"stale":     ignorePolicy,
+			},
+		},
+		"returns empty policies when only separators are given": {
+			str:  ",",
+			want: map[string]conclusionPolicy{},
+		},
+		"returns error when the pair is malformed": {
+			str:     "cancelled",
+			wantErr: true,
+		},
+		"returns error when the conclusion is unknown": {
+			str:     "unknown=success",
+			wantErr: true,
+		},
+		"returns error when the policy is unknown": {
+			str:     "cancelled=retry",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseConclusionPolicies(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseConclusionPolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseConclusionPolicies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_conclusionPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policies   map[string]conclusionPolicy
+		conclusion string
+		want       conclusionPolicy
+	}{
+		"returns success for success conclusion by default": {
+			conclusion: checkRunSuccessConclusion,
+			want:       successPolicy,
+		},
+		"returns success for neutral conclusion by default": {
+			conclusion: checkRunNeutralConclusion,
+			want:       successPolicy,
+		},
+		"returns ignore for skipped conclusion by default": {
+			conclusion: checkRunSkipConclusion,
+			want:       ignorePolicy,
+		},
+		"returns failure for cancelled conclusion by default": {
+			conclusion: "cancelled",
+			want:       failurePolicy,
+		},
+		"returns failure for unknown conclusion": {
+			conclusion: "unknown",
+			want:       failurePolicy,
+		},
+		"returns configured policy over default": {
+			policies:   map[string]conclusionPolicy{checkRunNeutralConclusion: failurePolicy},
+			conclusion: checkRunNeutralConclusion,
+			want:       failurePolicy,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				conclusionPolicies: tt.policies,
+			}
+			if got := sv.conclusionPolicy(tt.conclusion); got != tt.want {
+				t.Errorf("statusValidator.conclusionPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package status
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// matchApp reports whether the app matches any of the given slugs or IDs.
+func matchApp(app *github.App, apps []string) bool {
+	if app == nil {
+		return false
+	}
+	for _, a := range apps {
+		if strings.EqualFold(a, app.GetSlug()) || a == strconv.FormatInt(app.GetID(), 10) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCreator reports whether the user matches any of the given logins.
+func matchCreator(user *github.User, creators []string) bool {
+	if user == nil {
+		return false
+	}
+	for _, c := range creators {
+		if strings.EqualFold(c, user.GetLogin()) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptCheckRun reports whether the check run should be validated based on the app which created it.
+func (sv *statusValidator) acceptCheckRun(run *github.CheckRun) bool {
+	if len(sv.includedApps) != 0 && !matchApp(run.App, sv.includedApps) {
+		return false
+	}
+	return !matchApp(run.App, sv.excludedApps)
+}
+
+// acceptStatus reports whether the commit status should be validated based on the user who created it.
+func (sv *statusValidator) acceptStatus(s *github.RepoStatus) bool {
+	if len(sv.includedCreators) != 0 && !matchCreator(s.Creator, sv.includedCreators) {
+		return false
+	}
+	return !matchCreator(s.Creator, sv.excludedCreators)
+}
@@ -0,0 +1,114 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func Test_statusValidator_acceptCheckRun(t *testing.T) {
+	actions := &github.App{ID: int64Ptr(15368), Slug: stringPtr("github-actions")}
+	codecov := &github.App{ID: int64Ptr(254), Slug: stringPtr("codecov")}
+
+	tests := map[string]struct {
+		includedApps []string
+		excludedApps []string
+		run          *github.CheckRun
+		want         bool
+	}{
+		"accepts any check run when no filter is set": {
+			run:  &github.CheckRun{App: codecov},
+			want: true,
+		},
+		"accepts check run created by the included app slug": {
+			includedApps: []string{"GitHub-Actions"},
+			run:          &github.CheckRun{App: actions},
+			want:         true,
+		},
+		"accepts check run created by the included app ID": {
+			includedApps: []string{"15368"},
+			run:          &github.CheckRun{App: actions},
+			want:         true,
+		},
+		"rejects check run created by other than the included apps": {
+			includedApps: []string{"github-actions"},
+			run:          &github.CheckRun{App: codecov},
+			want:         false,
+		},
+		"rejects check run without app when apps are included": {
+			includedApps: []string{"github-actions"},
+			run:          &github.CheckRun{},
+			want:         false,
+		},
+		"rejects check run created by the excluded app": {
+			excludedApps: []string{"codecov"},
+			run:          &github.CheckRun{App: codecov},
+			want:         false,
+		},
+		"accepts check run created by other than the excluded apps": {
+			excludedApps: []string{"codecov"},
+			run:          &github.CheckRun{App: actions},
+			want:         true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				includedApps: tt.includedApps,
+				excludedApps: tt.excludedApps,
+			}
+			if got := sv.acceptCheckRun(tt.run); got != tt.want {
+				t.Errorf("statusValidator.acceptCheckRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_acceptStatus(t *testing.T) {
+	tests := map[string]struct {
+		includedCreators []string
+		excludedCreators []string
+		status           *github.RepoStatus
+		want             bool
+	}{
+		"accepts any status when no filter is set": {
+			status: &github.RepoStatus{Creator: &github.User{Login: stringPtr("external-ci")}},
+			want:   true,
+		},
+		"accepts status created by the included creator": {
+			includedCreators: []string{"external-ci"},
+			status:           &github.RepoStatus{Creator: &github.User{Login: stringPtr("External-CI")}},
+			want:             true,
+		},
+		"rejects status created by other than the included creators": {
+			includedCreators: []string{"external-ci"},
+			status:           &github.RepoStatus{Creator: &github.User{Login: stringPtr("someone")}},
+			want:             false,
+		},
+		"rejects status created by the excluded creator": {
+			excludedCreators: []string{"external-ci"},
+			status:           &github.RepoStatus{Creator: &github.User{Login: stringPtr("external-ci")}},
+			want:             false,
+		},
+		"accepts status without creator when creators are excluded": {
+			excludedCreators: []string{"external-ci"},
+			status:           &github.RepoStatus{},
+			want:             true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				includedCreators: tt.includedCreators,
+				excludedCreators: tt.excludedCreators,
+			}
+			if got := sv.acceptStatus(tt.status); got != tt.want {
+				t.Errorf("statusValidator.acceptStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package status
 
-import "strings"
+import (
+	"fmt"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/listutil"
+)
 
 type Option func(s *statusValidator)
 
@@ -12,6 +17,16 @@ func WithSelfJob(name string) Option {
 	}
 }
 
+// WithSelfCheckRun sets the name of the check run which reports the verdict of the validation,
+// so that the check run is considered as success like the self job.
+func WithSelfCheckRun(name string) Option {
+	return func(s *statusValidator) {
+		if len(name) != 0 {
+			s.selfCheckRunName = name
+		}
+	}
+}
+
 func WithGitHubOwnerAndRepo(owner, repo string) Option {
 	return func(s *statusValidator) {
 		if len(owner) != 0 {
@@ -31,6 +46,8 @@ func WithGitHubRef(ref string) Option {
 	}
 }
 
+// WithIgnoredJobs sets jobs to be ignored regardless of their statuses.
+// names is a comma-separated list of job names, glob patterns such as "lint*", or regular expressions prefixed with "re:".
 func WithIgnoredJobs(names string) Option {
 	return func(s *statusValidator) {
 		// TODO: Add more input validation, such as "," should not be a valid input.
@@ -38,15 +55,115 @@ func WithIgnoredJobs(names string) Option {
 			return // TODO: Return some clearer error
 		}
 
-		jobs := []string{}
-		ss := strings.Split(names, ",")
-		for _, s := range ss {
-			jobName := strings.TrimSpace(s)
-			if len(jobName) == 0 {
-				continue // TODO: Provide more clue to users
-			}
-			jobs = append(jobs, jobName)
+		patterns, err := parseJobPatterns(names)
+		if err != nil {
+			s.optionErrs = append(s.optionErrs, fmt.Errorf("ignored jobs are invalid: %w", err))
+			return
+		}
+		s.ignoredJobs = patterns
+	}
+}
+
+// WithRequiredJobs sets jobs which must be reported and succeed.
+// names is a comma-separated list of job names, glob patterns such as "test (*)", or regular expressions prefixed with "re:".
+// A job matching a required pattern is never ignored.
+func WithRequiredJobs(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+
+		patterns, err := parseJobPatterns(names)
+		if err != nil {
+			s.optionErrs = append(s.optionErrs, fmt.Errorf("required jobs are invalid: %w", err))
+			return
+		}
+		s.requiredJobs = patterns
+	}
+}
+
+// WithMinimumJobs sets the number of jobs, excluding this job itself and ignored jobs,
+// which must be reported before the validation can succeed.
+func WithMinimumJobs(n uint) Option {
+	return func(s *statusValidator) {
+		s.minJobs = int(n)
+	}
+}
+
+// WithSettleTime sets the grace period since the first validation, during which the validation cannot succeed
+// unless the minimum number of jobs set by WithMinimumJobs has been reported.
+func WithSettleTime(d time.Duration) Option {
+	return func(s *statusValidator) {
+		s.settleTime = d
+	}
+}
+
+// WithConclusionPolicies sets how completed check runs are treated based on their conclusions.
+// policies is a comma-separated list of "conclusion=policy" pairs, such as "cancelled=pending,neutral=failure",
+// where policy is one of success, failure, pending or ignore.
+func WithConclusionPolicies(policies string) Option {
+	return func(s *statusValidator) {
+		if len(policies) == 0 {
+			return
+		}
+
+		ps, err := parseConclusionPolicies(policies)
+		if err != nil {
+			s.optionErrs = append(s.optionErrs, fmt.Errorf("conclusion policies are invalid: %w", err))
+			return
+		}
+		s.conclusionPolicies = ps
+	}
+}
+
+// WithFailureWindow sets the period during which failed jobs are treated as retryable.
+// While the failure is within the window, the validation keeps waiting for the failed jobs to be re-run instead of failing immediately.
+func WithFailureWindow(d time.Duration) Option {
+	return func(s *statusValidator) {
+		s.failureWindow = d
+	}
+}
+
+// WithCheckRunApps sets the GitHub Apps whose check runs are validated, and the check runs created by other apps are disregarded.
+// apps is a comma-separated list of app slugs, such as "github-actions", or app IDs.
+func WithCheckRunApps(apps string) Option {
+	return func(s *statusValidator) {
+		if len(apps) == 0 {
+			return
+		}
+		s.includedApps = listutil.Split(apps)
+	}
+}
+
+// WithExcludedCheckRunApps sets the GitHub Apps whose check runs are disregarded.
+// apps is a comma-separated list of app slugs, such as "codecov", or app IDs.
+func WithExcludedCheckRunApps(apps string) Option {
+	return func(s *statusValidator) {
+		if len(apps) == 0 {
+			return
+		}
+		s.excludedApps = listutil.Split(apps)
+	}
+}
+
+// WithStatusCreators sets the users whose commit statuses are validated, and the commit statuses created by other users are disregarded.
+// creators is a comma-separated list of user logins.
+func WithStatusCreators(creators string) Option {
+	return func(s *statusValidator) {
+		if len(creators) == 0 {
+			return
+		}
+		s.includedCreators = listutil.Split(creators)
+	}
+}
+
+// WithExcludedStatusCreators sets the users whose commit statuses are disregarded.
+// creators is a comma-separated list of user logins.
+func WithExcludedStatusCreators(creators string) Option {
+	return func(s *statusValidator) {
+		if len(creators) == 0 {
+			return
 		}
-		s.ignoredJobs = jobs
+		s.excludedCreators = listutil.Split(creators)
 	}
 }
@@ -0,0 +1,99 @@
+package status
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+)
+
+const regexpPatternPrefix = "re:"
+
+// jobPattern matches job names.
+// A pattern prefixed with "re:" is treated as a regular expression, and any other pattern is treated as a glob,
+// where "*" matches any sequence of characters and "?" matches any single character.
+// A glob without any wildcard matches the job name exactly.
+type jobPattern struct {
+	raw     string
+	re      *regexp.Regexp
+	literal bool // whether the pattern is an exact job name
+}
+
+func newJobPattern(raw string) (*jobPattern, error) {
+	if strings.HasPrefix(raw, regexpPatternPrefix) {
+		expr := strings.TrimPrefix(raw, regexpPatternPrefix)
+		if len(expr) == 0 {
+			return nil, fmt.Errorf("regular expression of job pattern %q is empty", raw)
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("job pattern %q is invalid: %w", raw, err)
+		}
+		return &jobPattern{raw: raw, re: re}, nil
+	}
+
+	return &jobPattern{
+		raw:     raw,
+		re:      regexp.MustCompile(globToRegexp(raw)),
+		literal: !strings.ContainsAny(raw, "*?"),
+	}, nil
+}
+
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+func (p *jobPattern) match(job string) bool {
+	return p.re.MatchString(job)
+}
+
+func (p *jobPattern) String() string {
+	return p.raw
+}
+
+// parseJobPatterns parses a comma-separated list of job patterns.
+// Empty entries are skipped, and the returned slice is never nil.
+func parseJobPatterns(str string) ([]*jobPattern, error) {
+	patterns := []*jobPattern{}
+	var errs multierror.Errors
+	for _, s := range strings.Split(str, ",") {
+		raw := strings.TrimSpace(s)
+		if len(raw) == 0 {
+			continue // TODO: Provide more clue to users
+		}
+		p, err := newJobPattern(raw)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		patterns = append(patterns, p)
+	}
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	return patterns, nil
+}
+
+// findPattern returns the first pattern which matches the job name.
+func findPattern(patterns []*jobPattern, job string) (*jobPattern, bool) {
+	for _, p := range patterns {
+		if p.match(job) {
+			return p, true
+		}
+	}
+	return nil, false
+}
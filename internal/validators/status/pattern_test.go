@@ -0,0 +1,109 @@
+package status
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_jobPattern_match(t *testing.T) {
+	tests := map[string]struct {
+		raw  string
+		job  string
+		want bool
+	}{
+		"matches when the job name is the same": {
+			raw:  "job-01",
+			job:  "job-01",
+			want: true,
+		},
+		"does not match when the job name only has the same prefix": {
+			raw:  "job-01",
+			job:  "job-012",
+			want: false,
+		},
+		"matches matrix job with glob": {
+			raw:  "test (*)",
+			job:  "test (ubuntu-latest, 1.21)",
+			want: true,
+		},
+		"matches job name containing slash with glob": {
+			raw:  "e2e / *",
+			job:  "e2e / chrome / desktop",
+			want: true,
+		},
+		"matches a single character with question mark": {
+			raw:  "lint-?",
+			job:  "lint-a",
+			want: true,
+		},
+		"does not treat regular expression characters in glob specially": {
+			raw:  "build.go",
+			job:  "build-go",
+			want: false,
+		},
+		"matches with regular expression": {
+			raw:  "re:^deploy-.*$",
+			job:  "deploy-production",
+			want: true,
+		},
+		"does not match with regular expression": {
+			raw:  "re:^deploy-.*$",
+			job:  "pre-deploy-production",
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := newJobPattern(tt.raw)
+			if err != nil {
+				t.Fatalf("newJobPattern() error = %v", err)
+			}
+			if got := p.match(tt.job); got != tt.want {
+				t.Errorf("jobPattern.match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseJobPatterns(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    []string
+		wantErr bool
+	}{
+		"returns patterns from comma-separated list": {
+			str:  "job-01, lint* ,re:^deploy-.*$",
+			want: []string{"job-01", "lint*", "re:^deploy-.*$"},
+		},
+		"returns empty patterns when only separators are given": {
+			str:  ",",
+			want: []string{},
+		},
+		"returns error when regular expression is invalid": {
+			str:     "job-01,re:^deploy-(",
+			wantErr: true,
+		},
+		"returns error when regular expression is empty": {
+			str:     "re:",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			patterns, err := parseJobPatterns(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJobPatterns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := make([]string, 0, len(patterns))
+			for _, p := range patterns {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJobPatterns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,28 +1,38 @@
 package status
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
 
 type status struct {
 	totalJobs    []string
 	completeJobs []string
 	errJobs      []string
 	ignoredJobs  []string
-	succeeded    bool
+	ignoredBy    map[string]string // job name -> pattern which made the job ignored
+	missingJobs  []string          // required job patterns which no job has matched
+	waitingFor   string            // reason to keep waiting for other jobs to be reported
+	// retryingJobs holds failed jobs which are waited to be re-run, along with the remaining time.
+	retryingJobs []string
+	// supersededJobs holds older attempts of the jobs, which are not considered for the validation.
+	supersededJobs []string
+
+	// jobs holds the structured results of the jobs.
+	jobs      []*validators.Job
+	succeeded bool
 }
 
-func prettyPrintJobList(jobs []string) string {
-	result := ""
-	if len(jobs) == 0 {
-		result = "[]"
-	}
-	for i, job := range jobs {
-		result += fmt.Sprintf("- %s", job)
-		if i != len(jobs)-1 {
-			result += "\n"
+func (s *status) prettyPrintIgnoredJobs() string {
+	jobs := make([]string, 0, len(s.ignoredJobs))
+	for _, job := range s.ignoredJobs {
+		if p, ok := s.ignoredBy[job]; ok && p != job {
+			job = fmt.Sprintf("%s (matched by %s)", job, p)
 		}
+		jobs = append(jobs, job)
 	}
-
-	return result
+	return validators.PrettyPrintList(jobs)
 }
 
 func (s *status) Detail() string {
@@ -65,13 +75,61 @@ Ignored job count:     %d
 ::endgroup::
 `,
 		result,
-		prettyPrintJobList(s.errJobs),
-		prettyPrintJobList(s.completeJobs),
-		prettyPrintJobList(s.getIncompleteJobs()),
-		prettyPrintJobList(s.ignoredJobs),
-		prettyPrintJobList(s.totalJobs),
+		validators.PrettyPrintList(s.errJobs),
+		validators.PrettyPrintList(s.completeJobs),
+		validators.PrettyPrintList(s.getIncompleteJobs()),
+		s.prettyPrintIgnoredJobs(),
+		validators.PrettyPrintList(s.totalJobs),
 	)
 
+	if len(s.missingJobs) != 0 {
+		missing := make([]string, 0, len(s.missingJobs))
+		for _, job := range s.missingJobs {
+			missing = append(missing, fmt.Sprintf("%s: required job never reported", job))
+		}
+		result = fmt.Sprintf(`%s
+::group::Missing required jobs
+%s
+::endgroup::
+`,
+			result,
+			validators.PrettyPrintList(missing),
+		)
+	}
+
+	if len(s.retryingJobs) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Failed jobs waiting to be re-run
+%s
+::endgroup::
+`,
+			result,
+			validators.PrettyPrintList(s.retryingJobs),
+		)
+	}
+
+	if len(s.supersededJobs) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Superseded jobs
+%s
+::endgroup::
+`,
+			result,
+			validators.PrettyPrintList(s.supersededJobs),
+		)
+	}
+
+	if len(s.waitingFor) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Waiting for jobs to be reported
+%s
+::endgroup::
+`,
+			result,
+			s.waitingFor,
+		)
+	}
+
 	return result
 }
 
@@ -80,6 +138,10 @@ func (s *status) IsSuccess() bool {
 	return s.succeeded
 }
 
+func (s *status) Jobs() []*validators.Job {
+	return s.jobs
+}
+
 func (s *status) getIncompleteJobs() []string {
 	var incomplete []string
 
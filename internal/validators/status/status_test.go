@@ -104,6 +104,182 @@ Ignored job count:     1
 - job-3
 - job-4
 ::endgroup::
+`,
+		},
+		"return detail with the pattern which matched ignored jobs": {
+			s: &status{
+				totalJobs: []string{
+					"job-1",
+				},
+				completeJobs: []string{
+					"job-1",
+				},
+				ignoredJobs: []string{
+					"job-2",
+					"lint-go",
+				},
+				ignoredBy: map[string]string{
+					"job-2":   "job-2",
+					"lint-go": "lint*",
+				},
+			},
+			want: `1 out of 1
+
+Total job count:       1
+Completed job count:   1
+Incompleted job count: 0
+Failed job count:      0
+Ignored job count:     2
+
+::group::Failed jobs
+[]
+::endgroup::
+
+::group::Completed jobs
+- job-1
+::endgroup::
+
+::group::Incomplete jobs
+[]
+::endgroup::
+
+::group::Ignored jobs
+- job-2
+- lint-go (matched by lint*)
+::endgroup::
+
+::group::All jobs
+- job-1
+::endgroup::
+`,
+		},
+		"return detail with missing required jobs": {
+			s: &status{
+				totalJobs: []string{
+					"job-1",
+				},
+				completeJobs: []string{
+					"job-1",
+				},
+				missingJobs: []string{
+					"e2e / *",
+				},
+			},
+			want: `1 out of 1
+
+Total job count:       1
+Completed job count:   1
+Incompleted job count: 0
+Failed job count:      0
+Ignored job count:     0
+
+::group::Failed jobs
+[]
+::endgroup::
+
+::group::Completed jobs
+- job-1
+::endgroup::
+
+::group::Incomplete jobs
+[]
+::endgroup::
+
+::group::Ignored jobs
+[]
+::endgroup::
+
+::group::All jobs
+- job-1
+::endgroup::
+
+::group::Missing required jobs
+- e2e / *: required job never reported
+::endgroup::
+`,
+		},
+		"return detail when waiting for jobs to be reported": {
+			s: &status{
+				totalJobs:    []string{},
+				completeJobs: []string{},
+				waitingFor:   "0 out of 2 jobs reported",
+			},
+			want: `0 out of 0
+
+Total job count:       0
+Completed job count:   0
+Incompleted job count: 0
+Failed job count:      0
+Ignored job count:     0
+
+::group::Failed jobs
+[]
+::endgroup::
+
+::group::Completed jobs
+[]
+::endgroup::
+
+::group::Incomplete jobs
+[]
+::endgroup::
+
+::group::Ignored jobs
+[]
+::endgroup::
+
+::group::All jobs
+[]
+::endgroup::
+
+::group::Waiting for jobs to be reported
+0 out of 2 jobs reported
+::endgroup::
+`,
+		},
+		"return detail with superseded jobs": {
+			s: &status{
+				totalJobs: []string{
+					"job-1",
+				},
+				completeJobs: []string{
+					"job-1",
+				},
+				supersededJobs: []string{
+					"job-1 (error)",
+				},
+			},
+			want: `1 out of 1
+
+Total job count:       1
+Completed job count:   1
+Incompleted job count: 0
+Failed job count:      0
+Ignored job count:     0
+
+::group::Failed jobs
+[]
+::endgroup::
+
+::group::Completed jobs
+- job-1
+::endgroup::
+
+::group::Incomplete jobs
+[]
+::endgroup::
+
+::group::Ignored jobs
+[]
+::endgroup::
+
+::group::All jobs
+- job-1
+::endgroup::
+
+::group::Superseded jobs
+- job-1 (error)
+::endgroup::
 `,
 		},
 		"return detail when totalJobs and completeJobs is empty": {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/upsidr/merge-gatekeeper/internal/github"
 	"github.com/upsidr/merge-gatekeeper/internal/multierror"
@@ -15,6 +16,9 @@ const (
 	errorState   = "error"
 	failureState = "failure"
 	pendingState = "pending"
+
+	// ignoredState is only used internally for the check runs to be dropped based on their conclusions.
+	ignoredState = "ignored"
 )
 
 // NOTE: https://docs.github.com/en/rest/reference/checks
@@ -35,20 +39,125 @@ const (
 var (
 	ErrInvalidCombinedStatusResponse = errors.New("github combined status response is invalid")
 	ErrInvalidCheckRunResponse       = errors.New("github checkRun response is invalid")
+	ErrInconsistentPagination        = errors.New("github paginated response is inconsistent")
 )
 
 type ghaStatus struct {
 	Job   string
 	State string
+
+	Source     validators.JobSource
+	ID         int64  // ID of the commit status or the check run, which increases with new attempts
+	Conclusion string // raw state for commit statuses, or raw conclusion for check runs
+	URL        string
+
+	// StartedAt and UpdatedAt are used to find the most recent attempt of the job.
+	// UpdatedAt is completed_at for check runs, and updated_at for commit statuses.
+	StartedAt time.Time
+	UpdatedAt time.Time
+
+	// Superseded holds older attempts of the job.
+	Superseded []*ghaStatus
+}
+
+// newerThan reports whether s is a more recent attempt than other.
+// Attempts are compared by StartedAt first, and then by UpdatedAt.
+// When neither can tell, attempts from the same source are compared by their IDs, or by the order of the API response,
+// which lists the most recent attempt first.
+// Attempts from different sources have no order, and thus a pending attempt is regarded as a re-run in progress,
+// and a failure is preferred over a success, so that the result does not depend on which source is listed first.
+func (s *ghaStatus) newerThan(other *ghaStatus) bool {
+	if !s.StartedAt.IsZero() && !other.StartedAt.IsZero() && !s.StartedAt.Equal(other.StartedAt) {
+		return s.StartedAt.After(other.StartedAt)
+	}
+	if !s.UpdatedAt.IsZero() && !other.UpdatedAt.IsZero() && !s.UpdatedAt.Equal(other.UpdatedAt) {
+		return s.UpdatedAt.After(other.UpdatedAt)
+	}
+	if s.Source == other.Source {
+		return s.ID != 0 && other.ID != 0 && s.ID > other.ID
+	}
+	if (s.State == pendingState) != (other.State == pendingState) {
+		return s.State == pendingState
+	}
+	return stateRank(s.State) > stateRank(other.State)
+}
+
+// stateRank orders the states of attempts which cannot be told apart by time, from the most blocking one.
+func stateRank(state string) int {
+	switch state {
+	case errorState, failureState:
+		return 2
+	case successState:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (s *ghaStatus) toJob(state validators.JobState) *validators.Job {
+	job := &validators.Job{
+		Name:       s.Job,
+		Source:     s.Source,
+		State:      state,
+		Conclusion: s.Conclusion,
+		URL:        s.URL,
+	}
+	if !s.StartedAt.IsZero() {
+		startedAt := s.StartedAt
+		job.StartedAt = &startedAt
+	}
+	// NOTE: updated_at of commit statuses is regarded as completion time only when they are no longer pending.
+	if !s.UpdatedAt.IsZero() && s.State != pendingState {
+		completedAt := s.UpdatedAt
+		job.CompletedAt = &completedAt
+	}
+	return job
+}
+
+// supersede merges another attempt of the same job into s, so that s holds the most recent attempt
+// and the older attempts are kept in Superseded.
+func (s *ghaStatus) supersede(other *ghaStatus) {
+	if !other.newerThan(s) {
+		s.Superseded = append(s.Superseded, other)
+		return
+	}
+	older := *s
+	older.Superseded = nil
+	superseded := append(s.Superseded, &older)
+	*s = *other
+	s.Superseded = superseded
 }
 
 type statusValidator struct {
-	repo        string
-	owner       string
-	ref         string
-	selfJobName string
-	ignoredJobs []string
-	client      github.Client
+	repo         string
+	owner        string
+	ref          string
+	selfJobName  string
+	ignoredJobs  []*jobPattern
+	requiredJobs []*jobPattern
+	client       github.Client
+
+	// selfCheckRunName is the name of the check run created by the validator itself to report its verdict.
+	selfCheckRunName string
+
+	minJobs    int
+	settleTime time.Duration
+	startedAt  time.Time // time of the first validation
+
+	conclusionPolicies map[string]conclusionPolicy
+
+	// includedApps and excludedApps hold slugs or IDs of the GitHub Apps which create check runs,
+	// and includedCreators and excludedCreators hold logins of the users who create commit statuses.
+	includedApps     []string
+	excludedApps     []string
+	includedCreators []string
+	excludedCreators []string
+
+	failureWindow time.Duration
+	failedSince   map[string]time.Time // job name -> time when the failure was first observed
+
+	// optionErrs holds errors which occurred while applying options.
+	optionErrs []error
 }
 
 func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
@@ -83,9 +192,19 @@ func (sv *statusValidator) validateFields() error {
 	if len(sv.selfJobName) == 0 {
 		errs = append(errs, errors.New("self job name is empty"))
 	}
+	if sv.minJobs < 0 {
+		errs = append(errs, errors.New("minimum job count is negative"))
+	}
+	if sv.settleTime < 0 {
+		errs = append(errs, errors.New("settle time is negative"))
+	}
+	if sv.failureWindow < 0 {
+		errs = append(errs, errors.New("failure window is negative"))
+	}
 	if sv.client == nil {
 		errs = append(errs, errors.New("github client is empty"))
 	}
+	errs = append(errs, sv.optionErrs...)
 
 	if len(errs) != 0 {
 		return errs
@@ -95,7 +214,11 @@ func (sv *statusValidator) validateFields() error {
 }
 
 func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, error) {
-	ghaStatuses, err := sv.listGhaStatuses(ctx)
+	if sv.startedAt.IsZero() {
+		sv.startedAt = time.Now()
+	}
+
+	ghaStatuses, unlistedCheckRuns, err := sv.listGhaStatuses(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -105,23 +228,72 @@ func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, err
 		completeJobs: make([]string, 0, len(ghaStatuses)),
 		errJobs:      make([]string, 0, len(ghaStatuses)/2),
 		ignoredJobs:  make([]string, 0, len(ghaStatuses)),
+		ignoredBy:    make(map[string]string),
 		succeeded:    true,
 	}
 
-	st.ignoredJobs = append(st.ignoredJobs, sv.ignoredJobs...)
+	reported := make(map[*jobPattern]struct{}, len(sv.requiredJobs))
+
+	// Exact job names to ignore are listed even when they are not reported, and patterns list the jobs they match.
+	ignored := make(map[string]struct{}, len(sv.ignoredJobs))
+	addIgnored := func(job string) {
+		if _, ok := ignored[job]; ok {
+			return
+		}
+		ignored[job] = struct{}{}
+		st.ignoredJobs = append(st.ignoredJobs, job)
+	}
+	for _, p := range sv.ignoredJobs {
+		if p.literal {
+			addIgnored(p.String())
+		}
+	}
 
 	var successCnt int
 	for _, ghaStatus := range ghaStatuses {
-		var toIgnore bool
-		for _, ignored := range sv.ignoredJobs {
-			if ghaStatus.Job == ignored {
-				toIgnore = true
-				break
+		// This job itself should be considered as success regardless of its status.
+		if ghaStatus.Job == sv.selfJobName {
+			successCnt++
+			continue
+		}
+		// The check run reporting the verdict is pending until the validation ends, so it must not be waited for.
+		if len(sv.selfCheckRunName) != 0 && ghaStatus.Source == validators.JobSourceCheckRun && ghaStatus.Job == sv.selfCheckRunName {
+			successCnt++
+			continue
+		}
+
+		for _, superseded := range ghaStatus.Superseded {
+			st.supersededJobs = append(st.supersededJobs, fmt.Sprintf("%s (%s)", superseded.Job, superseded.State))
+			st.jobs = append(st.jobs, superseded.toJob(validators.JobStateSuperseded))
+		}
+
+		var required bool
+		for _, p := range sv.requiredJobs {
+			if p.match(ghaStatus.Job) {
+				reported[p] = struct{}{}
+				required = true
 			}
 		}
 
-		// Ignored jobs and this job itself should be considered as success regardless of their statuses.
-		if toIgnore || ghaStatus.Job == sv.selfJobName {
+		// Jobs whose latest attempt is ignored based on the conclusion, such as skipped jobs, are considered as success.
+		// Required jobs are also considered as success, because GitHub treats skipped required checks as passing,
+		// and they are matched against the required patterns above so that they are not reported as missing.
+		if ghaStatus.State == ignoredState {
+			addIgnored(ghaStatus.Job)
+			job := ghaStatus.toJob(validators.JobStateIgnored)
+			job.IgnoreReason = fmt.Sprintf("concluded as %s", ghaStatus.Conclusion)
+			st.jobs = append(st.jobs, job)
+			successCnt++
+			continue
+		}
+
+		// Ignored jobs should be considered as success regardless of their statuses, unless they are required.
+		if p, ok := findPattern(sv.ignoredJobs, ghaStatus.Job); ok && !required {
+			addIgnored(ghaStatus.Job)
+			st.ignoredBy[ghaStatus.Job] = p.String()
+			job := ghaStatus.toJob(validators.JobStateIgnored)
+			job.IgnoreReason = fmt.Sprintf("matched ignored job pattern %s", p)
+			st.jobs = append(st.jobs, job)
 			successCnt++
 			continue
 		}
@@ -131,16 +303,41 @@ func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, err
 		switch ghaStatus.State {
 		case successState:
 			st.completeJobs = append(st.completeJobs, ghaStatus.Job)
+			st.jobs = append(st.jobs, ghaStatus.toJob(validators.JobStateSuccess))
 			successCnt++
 		case errorState, failureState:
 			st.errJobs = append(st.errJobs, ghaStatus.Job)
+			st.jobs = append(st.jobs, ghaStatus.toJob(validators.JobStateFailure))
+		default:
+			st.jobs = append(st.jobs, ghaStatus.toJob(validators.JobStatePending))
+		}
+	}
+	// Failed jobs can be re-run while waiting on failures, and the validation fails only when the failure window expires.
+	st.retryingJobs = sv.retryableFailures(st.errJobs)
+	if len(st.errJobs) != 0 && len(st.retryingJobs) == 0 {
+		// The status is returned along with the error, so that the failed jobs can be reported.
+		st.succeeded = false
+		return st, errors.New(st.Detail())
+	}
+
+	// Required jobs which have never been reported keep the validation incomplete.
+	for _, p := range sv.requiredJobs {
+		if _, ok := reported[p]; !ok {
+			st.missingJobs = append(st.missingJobs, p.String())
+			st.jobs = append(st.jobs, &validators.Job{
+				Name:  p.String(),
+				State: validators.JobStateMissing,
+			})
 		}
 	}
-	if len(st.errJobs) != 0 {
-		return nil, errors.New(st.Detail())
+
+	st.waitingFor = sv.waitingFor(len(st.totalJobs))
+	if len(st.waitingFor) == 0 && unlistedCheckRuns != 0 {
+		// Check runs created while listing them are listed on the next poll.
+		st.waitingFor = fmt.Sprintf("%d check runs changed while listing them", unlistedCheckRuns)
 	}
 
-	if len(ghaStatuses) != successCnt {
+	if len(ghaStatuses) != successCnt || len(st.missingJobs) != 0 || len(st.waitingFor) != 0 {
 		st.succeeded = false
 		return st, nil
 	}
@@ -148,28 +345,89 @@ func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, err
 	return st, nil
 }
 
+// waitingFor returns the reason why the validation should keep waiting for other jobs to be reported,
+// which guards against succeeding before other workflows have even been queued.
+// It returns an empty string when there is no need to wait.
+func (sv *statusValidator) waitingFor(reported int) string {
+	if sv.minJobs == 0 && sv.settleTime == 0 {
+		return ""
+	}
+	if sv.minJobs > 0 && reported >= sv.minJobs {
+		return ""
+	}
+	elapsed := time.Since(sv.startedAt)
+	if sv.settleTime > 0 && elapsed >= sv.settleTime {
+		return ""
+	}
+
+	switch {
+	case sv.minJobs > 0 && sv.settleTime > 0:
+		return fmt.Sprintf("%d out of %d jobs reported, settle time %s remaining", reported, sv.minJobs, (sv.settleTime - elapsed).Round(time.Second))
+	case sv.minJobs > 0:
+		return fmt.Sprintf("%d out of %d jobs reported", reported, sv.minJobs)
+	default:
+		return fmt.Sprintf("settle time %s remaining", (sv.settleTime - elapsed).Round(time.Second))
+	}
+}
+
+// retryableFailures returns the failed jobs which can still be re-run within the failure window, along with the remaining time.
+// It returns nil when the failure window is not set, or when any of the failures has outlived the failure window.
+func (sv *statusValidator) retryableFailures(errJobs []string) []string {
+	if sv.failureWindow == 0 || len(errJobs) == 0 {
+		sv.failedSince = nil
+		return nil
+	}
+
+	// Failures which are no longer the latest attempts are forgotten, so that another failure of a re-run gets a new window.
+	failedSince := make(map[string]time.Time, len(errJobs))
+	now := time.Now()
+	for _, job := range errJobs {
+		since, ok := sv.failedSince[job]
+		if !ok {
+			since = now
+		}
+		failedSince[job] = since
+	}
+	sv.failedSince = failedSince
+
+	retrying := make([]string, 0, len(errJobs))
+	for _, job := range errJobs {
+		remaining := sv.failureWindow - now.Sub(failedSince[job])
+		if remaining <= 0 {
+			return nil
+		}
+		retrying = append(retrying, fmt.Sprintf("%s: %s remaining", job, remaining.Round(time.Second)))
+	}
+	return retrying
+}
+
 func (sv *statusValidator) getCombinedStatus(ctx context.Context) ([]*github.RepoStatus, error) {
 	var combined []*github.RepoStatus
-	page := 1
-	for {
-		c, _, err := sv.client.GetCombinedStatus(ctx, sv.owner, sv.repo, sv.ref, &github.ListOptions{PerPage: maxStatusesPerPage, Page: page})
+	err := github.Paginate(github.MaxPages, "statuses", func(page int) (*github.Response, error) {
+		c, resp, err := sv.client.GetCombinedStatus(ctx, sv.owner, sv.repo, sv.ref, &github.ListOptions{PerPage: maxStatusesPerPage, Page: page})
 		if err != nil {
 			return nil, err
 		}
 		combined = append(combined, c.Statuses...)
-		if c.GetTotalCount() < maxStatusesPerPage {
-			break
+
+		// NOTE: TotalCount is the total across all pages, so the next page must be decided by the Link header.
+		if resp != nil && resp.NextPage != 0 && len(c.Statuses) == 0 {
+			return nil, fmt.Errorf("%w: page %d is empty, but next page %d exists", ErrInconsistentPagination, page, resp.NextPage)
 		}
-		page++
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return combined, nil
 }
 
-func (sv *statusValidator) listCheckRunsForRef(ctx context.Context) ([]*github.CheckRun, error) {
+// listCheckRunsForRef returns the check runs, along with the number of check runs which are counted in the total but not listed.
+func (sv *statusValidator) listCheckRunsForRef(ctx context.Context) ([]*github.CheckRun, int, error) {
 	var runResults []*github.CheckRun
-	page := 1
-	for {
-		cr, _, err := sv.client.ListCheckRunsForRef(ctx, sv.owner, sv.repo, sv.ref, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{
+	total := 0
+	err := github.Paginate(github.MaxPages, "check runs", func(page int) (*github.Response, error) {
+		cr, resp, err := sv.client.ListCheckRunsForRef(ctx, sv.owner, sv.repo, sv.ref, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{
 			Page:    page,
 			PerPage: maxCheckRunsPerPage,
 		}})
@@ -177,74 +435,106 @@ func (sv *statusValidator) listCheckRunsForRef(ctx context.Context) ([]*github.C
 			return nil, err
 		}
 		runResults = append(runResults, cr.CheckRuns...)
-		if cr.GetTotal() <= len(runResults) {
-			break
+		total = cr.GetTotal()
+
+		if resp != nil && resp.NextPage != 0 && len(cr.CheckRuns) == 0 {
+			return nil, fmt.Errorf("%w: page %d is empty, but next page %d exists", ErrInconsistentPagination, page, resp.NextPage)
 		}
-		page++
+		return resp, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	// NOTE: Fewer check runs than the total are not an error, because check runs can be created or removed between the pages.
+	unlisted := total - len(runResults)
+	if unlisted < 0 {
+		unlisted = 0
 	}
-	return runResults, nil
+	return runResults, unlisted, nil
 }
 
-func (sv *statusValidator) listGhaStatuses(ctx context.Context) ([]*ghaStatus, error) {
+// listGhaStatuses returns the latest attempt of each job, along with the number of check runs which are not listed.
+func (sv *statusValidator) listGhaStatuses(ctx context.Context) ([]*ghaStatus, int, error) {
 	combined, err := sv.getCombinedStatus(ctx)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	// Because multiple jobs with the same name may exist when jobs are created dynamically by third-party tools, etc.,
-	// only the latest job should be managed.
-	currentJobs := make(map[string]struct{})
+	// Because multiple jobs with the same name may exist when jobs are re-run, or created dynamically by third-party tools, etc.,
+	// only the most recent attempt of each job should be managed.
+	latestJobs := make(map[string]*ghaStatus)
 
 	ghaStatuses := make([]*ghaStatus, 0, len(combined))
+	add := func(st *ghaStatus) {
+		latest, ok := latestJobs[st.Job]
+		if !ok {
+			latestJobs[st.Job] = st
+			ghaStatuses = append(ghaStatuses, st)
+			return
+		}
+		latest.supersede(st)
+	}
+
 	for _, s := range combined {
 		if s.Context == nil || s.State == nil {
-			return nil, fmt.Errorf("%w context: %v, status: %v", ErrInvalidCombinedStatusResponse, s.Context, s.State)
+			return nil, 0, fmt.Errorf("%w context: %v, status: %v", ErrInvalidCombinedStatusResponse, s.Context, s.State)
 		}
-		if _, ok := currentJobs[*s.Context]; ok {
+		if !sv.acceptStatus(s) {
 			continue
 		}
-		currentJobs[*s.Context] = struct{}{}
 
-		ghaStatuses = append(ghaStatuses, &ghaStatus{
-			Job:   *s.Context,
-			State: *s.State,
+		add(&ghaStatus{
+			Job:        *s.Context,
+			State:      *s.State,
+			Source:     validators.JobSourceStatus,
+			ID:         s.GetID(),
+			Conclusion: *s.State,
+			URL:        s.GetTargetURL(),
+			UpdatedAt:  s.GetUpdatedAt(),
 		})
 	}
 
-	runResults, err := sv.listCheckRunsForRef(ctx)
+	runResults, unlisted, err := sv.listCheckRunsForRef(ctx)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	for _, run := range runResults {
 		if run.Name == nil || run.Status == nil {
-			return nil, fmt.Errorf("%w name: %v, status: %v", ErrInvalidCheckRunResponse, run.Name, run.Status)
+			return nil, 0, fmt.Errorf("%w name: %v, status: %v", ErrInvalidCheckRunResponse, run.Name, run.Status)
 		}
-		if _, ok := currentJobs[*run.Name]; ok {
+		if !sv.acceptCheckRun(run) {
 			continue
 		}
-		currentJobs[*run.Name] = struct{}{}
 
 		ghaStatus := &ghaStatus{
-			Job: *run.Name,
+			Job:        *run.Name,
+			Source:     validators.JobSourceCheckRun,
+			ID:         run.GetID(),
+			Conclusion: run.GetConclusion(),
+			URL:        run.GetHTMLURL(),
+			StartedAt:  run.GetStartedAt().Time,
+			UpdatedAt:  run.GetCompletedAt().Time,
 		}
 
 		if *run.Status != checkRunCompletedStatus {
 			ghaStatus.State = pendingState
-			ghaStatuses = append(ghaStatuses, ghaStatus)
+			add(ghaStatus)
 			continue
 		}
 
-		switch *run.Conclusion {
-		case checkRunNeutralConclusion, checkRunSuccessConclusion:
+		switch sv.conclusionPolicy(run.GetConclusion()) {
+		case successPolicy:
 			ghaStatus.State = successState
-		case checkRunSkipConclusion:
-			continue
+		case pendingPolicy:
+			ghaStatus.State = pendingState
+		case ignorePolicy:
+			ghaStatus.State = ignoredState
 		default:
 			ghaStatus.State = errorState
 		}
-		ghaStatuses = append(ghaStatuses, ghaStatus)
+		add(ghaStatus)
 	}
 
-	return ghaStatuses, nil
+	return ghaStatuses, unlisted, nil
 }
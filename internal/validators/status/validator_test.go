@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/upsidr/merge-gatekeeper/internal/github"
 	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
@@ -16,11 +17,24 @@ func stringPtr(str string) *string {
 	return &str
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
+func timestampPtr(t time.Time) *github.Timestamp {
+	return &github.Timestamp{Time: t}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func mustJobPatterns(raws ...string) []*jobPattern {
+	patterns := make([]*jobPattern, 0, len(raws))
+	for _, raw := range raws {
+		p, err := newJobPattern(raw)
+		if err != nil {
+			panic(err)
+		}
+		patterns = append(patterns, p)
 	}
-	return b
+	return patterns
 }
 
 func TestCreateValidator(t *testing.T) {
@@ -44,7 +58,7 @@ func TestCreateValidator(t *testing.T) {
 				repo:        "test-repo",
 				ref:         "sha",
 				selfJobName: "job",
-				ignoredJobs: []string{"job-01", "job-02"},
+				ignoredJobs: mustJobPatterns("job-01", "job-02"),
 			},
 			wantErr: false,
 		},
@@ -82,7 +96,120 @@ func TestCreateValidator(t *testing.T) {
 				repo:        "test-repo",
 				ref:         "sha-01",
 				selfJobName: "job-01",
-				ignoredJobs: []string{}, // Not nil
+				ignoredJobs: []*jobPattern{}, // Not nil
+			},
+			wantErr: false,
+		},
+		"returns error when ignored jobs contain invalid regular expression": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithIgnoredJobs("job-01,re:^job-("),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns Validator with required jobs": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithRequiredJobs("build, test (*)"),
+			},
+			want: &statusValidator{
+				client:       &mock.Client{},
+				owner:        "test-owner",
+				repo:         "test-repo",
+				ref:          "sha",
+				selfJobName:  "job",
+				requiredJobs: mustJobPatterns("build", "test (*)"),
+			},
+			wantErr: false,
+		},
+		"returns error when required jobs contain invalid regular expression": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithRequiredJobs("re:[a-"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns Validator with minimum jobs and settle time": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithMinimumJobs(3),
+				WithSettleTime(30 * time.Second),
+			},
+			want: &statusValidator{
+				client:      &mock.Client{},
+				owner:       "test-owner",
+				repo:        "test-repo",
+				ref:         "sha",
+				selfJobName: "job",
+				minJobs:     3,
+				settleTime:  30 * time.Second,
+			},
+			wantErr: false,
+		},
+		"returns error when settle time is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithSettleTime(-1 * time.Second),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns Validator with failure window": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithFailureWindow(5 * time.Minute),
+			},
+			want: &statusValidator{
+				client:        &mock.Client{},
+				owner:         "test-owner",
+				repo:          "test-repo",
+				ref:           "sha",
+				selfJobName:   "job",
+				failureWindow: 5 * time.Minute,
+			},
+			wantErr: false,
+		},
+		"returns Validator with app and creator filters": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithCheckRunApps("github-actions"),
+				WithExcludedCheckRunApps("codecov, 254"),
+				WithStatusCreators("external-ci"),
+				WithExcludedStatusCreators("dependabot[bot]"),
+			},
+			want: &statusValidator{
+				client:           &mock.Client{},
+				owner:            "test-owner",
+				repo:             "test-repo",
+				ref:              "sha",
+				selfJobName:      "job",
+				includedApps:     []string{"github-actions"},
+				excludedApps:     []string{"codecov", "254"},
+				includedCreators: []string{"external-ci"},
+				excludedCreators: []string{"dependabot[bot]"},
 			},
 			wantErr: false,
 		},
@@ -151,13 +278,16 @@ func TestName(t *testing.T) {
 
 func Test_statusValidator_Validate(t *testing.T) {
 	type test struct {
-		selfJobName string
-		ignoredJobs []string
-		client      github.Client
-		ctx         context.Context
-		wantErr     bool
-		wantErrStr  string
-		wantStatus  validators.Status
+		selfJobName      string
+		selfCheckRunName string
+		ignoredJobs      []*jobPattern
+		requiredJobs     []*jobPattern
+		minJobs          int
+		client           github.Client
+		ctx              context.Context
+		wantErr          bool
+		wantErrStr       string
+		wantStatus       validators.Status
 	}
 	tests := map[string]test{
 		"returns error when listGhaStatuses return an error": {
@@ -185,6 +315,7 @@ func Test_statusValidator_Validate(t *testing.T) {
 				totalJobs:    []string{},
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
 				errJobs:      []string{},
 			},
 		},
@@ -211,6 +342,7 @@ func Test_statusValidator_Validate(t *testing.T) {
 				totalJobs:    []string{},
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
 				errJobs:      []string{},
 			},
 		},
@@ -236,7 +368,16 @@ func Test_statusValidator_Validate(t *testing.T) {
 				totalJobs:    []string{"job"},
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
 				errJobs:      []string{},
+				jobs: []*validators.Job{
+					{
+						Name:       "job",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStatePending,
+						Conclusion: pendingState,
+					},
+				},
 			},
 		},
 		"returns error when there is a failed job": {
@@ -277,6 +418,28 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				ignoredJobs: []string{},
 			}).Detail(),
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"job-02"},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:       "job-02",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateFailure,
+						Conclusion: errorState,
+					},
+				},
+			},
 		},
 		"returns error when there is a failed job with failure state": {
 			selfJobName: "self-job",
@@ -316,6 +479,28 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				ignoredJobs: []string{},
 			}).Detail(),
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"job-02"},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:       "job-02",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateFailure,
+						Conclusion: failureState,
+					},
+				},
+			},
 		},
 		"returns failed status and nil when successful job count is less than total": {
 			selfJobName: "self-job",
@@ -354,6 +539,21 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				errJobs:     []string{},
 				ignoredJobs: []string{},
+				ignoredBy:   map[string]string{},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:       "job-02",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStatePending,
+						Conclusion: pendingState,
+					},
+				},
 			},
 		},
 		"returns succeeded status and nil when validation is success": {
@@ -394,11 +594,73 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				errJobs:     []string{},
 				ignoredJobs: []string{},
+				ignoredBy:   map[string]string{},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:       "job-02",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+				},
+			},
+		},
+		"returns succeeded status and nil when the self check run is in progress": {
+			selfJobName:      "self-job",
+			selfCheckRunName: "Merge Gatekeeper",
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:   stringPtr("Merge Gatekeeper"),
+								Status: stringPtr("in_progress"),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded: true,
+				totalJobs: []string{
+					"job-01",
+				},
+				completeJobs: []string{
+					"job-01",
+				},
+				errJobs:     []string{},
+				ignoredJobs: []string{},
+				ignoredBy:   map[string]string{},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+				},
 			},
 		},
 		"returns succeeded status and nil when only an ignored job is failing": {
 			selfJobName: "self-job",
-			ignoredJobs: []string{"job-02", "job-03"}, // String input here should be already TrimSpace'd
+			ignoredJobs: mustJobPatterns("job-02", "job-03"), // String input here should be already TrimSpace'd
 			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
 					return &github.CombinedStatus{
@@ -429,11 +691,27 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{"job-01"},
 				errJobs:      []string{},
 				ignoredJobs:  []string{"job-02", "job-03"},
+				ignoredBy:    map[string]string{"job-02": "job-02"},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:         "job-02",
+						Source:       validators.JobSourceStatus,
+						State:        validators.JobStateIgnored,
+						Conclusion:   errorState,
+						IgnoreReason: "matched ignored job pattern job-02",
+					},
+				},
 			},
 		},
 		"returns succeeded status and nil when only an ignored job is failing, with failure state": {
 			selfJobName: "self-job",
-			ignoredJobs: []string{"job-02", "job-03"},
+			ignoredJobs: mustJobPatterns("job-02", "job-03"),
 			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
 					return &github.CombinedStatus{
@@ -464,135 +742,1191 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{"job-01"},
 				errJobs:      []string{},
 				ignoredJobs:  []string{"job-02", "job-03"},
+				ignoredBy:    map[string]string{"job-02": "job-02"},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:         "job-02",
+						Source:       validators.JobSourceStatus,
+						State:        validators.JobStateIgnored,
+						Conclusion:   failureState,
+						IgnoreReason: "matched ignored job pattern job-02",
+					},
+				},
 			},
 		},
-	}
-	for name, tt := range tests {
-		t.Run(name, func(t *testing.T) {
-			sv := &statusValidator{
-				selfJobName: tt.selfJobName,
-				ignoredJobs: tt.ignoredJobs,
-				client:      tt.client,
-			}
-			got, err := sv.Validate(tt.ctx)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if tt.wantErr {
-				if err.Error() != tt.wantErrStr {
-					t.Errorf("statusValidator.Validate() error.Error() = %s, wantErrStr %s", err.Error(), tt.wantErrStr)
-				}
-			}
-			if !reflect.DeepEqual(got, tt.wantStatus) {
-				t.Errorf("statusValidator.Validate() status = %v, want %v", got, tt.wantStatus)
-			}
-		})
-	}
-}
-
-func Test_statusValidator_listStatuses(t *testing.T) {
-	type fields struct {
-		repo        string
-		owner       string
-		ref         string
-		selfJobName string
-		client      github.Client
-	}
-	type test struct {
-		fields  fields
-		ctx     context.Context
-		wantErr bool
-		want    []*ghaStatus
-	}
-	tests := map[string]test{
-		"succeeds to get job statuses even if the same job exists": func() test {
-			c := &mock.Client{
+		"returns succeeded status and nil when failing jobs match ignored patterns": {
+			selfJobName: "self-job",
+			ignoredJobs: mustJobPatterns("lint*", "re:^deploy-.*$"),
+			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
 					return &github.CombinedStatus{
 						Statuses: []*github.RepoStatus{
-							// The first element here is the latest state.
 							{
 								Context: stringPtr("job-01"),
 								State:   stringPtr(successState),
 							},
 							{
-								Context: stringPtr("job-01"), // Same as above job name, and thus should be disregarded as old job status.
-								State:   stringPtr(errorState),
-							},
-						},
-					}, nil, nil
-				},
-				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					return &github.ListCheckRunsResults{
-						CheckRuns: []*github.CheckRun{
-							// The first element here is the latest state.
-							{
-								Name:   stringPtr("job-02"),
-								Status: stringPtr("failure"),
-							},
-							{
-								Name:       stringPtr("job-02"), // Same as above job name, and thus should be disregarded as old job status.
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunNeutralConclusion),
-							},
-							{
-								Name:       stringPtr("job-03"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunNeutralConclusion),
-							},
-							{
-								Name:       stringPtr("job-04"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunSuccessConclusion),
+								Context: stringPtr("lint-go"),
+								State:   stringPtr(failureState),
 							},
 							{
-								Name:       stringPtr("job-05"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr("failure"),
+								Context: stringPtr("deploy-staging"),
+								State:   stringPtr(errorState),
 							},
 							{
-								Name:       stringPtr("job-06"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunSkipConclusion),
+								Context: stringPtr("self-job"),
+								State:   stringPtr(pendingState),
 							},
 						},
 					}, nil, nil
 				},
-			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
 				},
-				wantErr: false,
-				want: []*ghaStatus{
-					{
-						Job:   "job-01",
-						State: successState,
-					},
-					{
-						Job:   "job-02",
-						State: pendingState,
-					},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{"lint-go", "deploy-staging"},
+				ignoredBy: map[string]string{
+					"lint-go":        "lint*",
+					"deploy-staging": "re:^deploy-.*$",
+				},
+				jobs: []*validators.Job{
 					{
-						Job:   "job-03",
-						State: successState,
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
 					},
 					{
-						Job:   "job-04",
-						State: successState,
+						Name:         "lint-go",
+						Source:       validators.JobSourceStatus,
+						State:        validators.JobStateIgnored,
+						Conclusion:   failureState,
+						IgnoreReason: "matched ignored job pattern lint*",
 					},
 					{
-						Job:   "job-05",
-						State: errorState,
+						Name:         "deploy-staging",
+						Source:       validators.JobSourceStatus,
+						State:        validators.JobStateIgnored,
+						Conclusion:   errorState,
+						IgnoreReason: "matched ignored job pattern re:^deploy-.*$",
 					},
 				},
-			}
-		}(),
+			},
+		},
+		"returns ignored job names even when they are not reported, and only matched jobs for wildcards": {
+			selfJobName: "self-job",
+			ignoredJobs: mustJobPatterns("job-02", "lint-?", "build*"),
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("lint-a"),
+								State:   stringPtr(failureState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{"job-02", "lint-a"},
+				ignoredBy:    map[string]string{"lint-a": "lint-?"},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:         "lint-a",
+						Source:       validators.JobSourceStatus,
+						State:        validators.JobStateIgnored,
+						Conclusion:   failureState,
+						IgnoreReason: "matched ignored job pattern lint-?",
+					},
+				},
+			},
+		},
+		"returns failed status and nil when a required job is never reported": {
+			selfJobName:  "self-job",
+			requiredJobs: mustJobPatterns("job-01", "e2e / *"),
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("self-job"),
+								State:   stringPtr(pendingState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				missingJobs:  []string{"e2e / *"},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:  "e2e / *",
+						State: validators.JobStateMissing,
+					},
+				},
+			},
+		},
+		"returns succeeded status and nil when all required jobs are reported and succeeded": {
+			selfJobName:  "self-job",
+			requiredJobs: mustJobPatterns("job-01", "e2e / *"),
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("e2e / chrome"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01", "e2e / chrome"},
+				completeJobs: []string{"job-01", "e2e / chrome"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:       "e2e / chrome",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+				},
+			},
+		},
+		"returns succeeded status and nil when a required job is skipped": {
+			selfJobName:  "self-job",
+			requiredJobs: mustJobPatterns("deploy"),
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("deploy"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSkipConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{},
+				completeJobs: []string{},
+				errJobs:      []string{},
+				ignoredJobs:  []string{"deploy"},
+				ignoredBy:    map[string]string{},
+				jobs: []*validators.Job{
+					{
+						Name:         "deploy",
+						Source:       validators.JobSourceCheckRun,
+						State:        validators.JobStateIgnored,
+						Conclusion:   checkRunSkipConclusion,
+						IgnoreReason: "concluded as skipped",
+					},
+				},
+			},
+		},
+		"returns pending status and nil when check runs change while listing them": {
+			selfJobName: "self-job",
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					total := 2
+					return &github.ListCheckRunsResults{
+						Total: &total,
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("build"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"build"},
+				completeJobs: []string{"build"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				waitingFor:   "1 check runs changed while listing them",
+				jobs: []*validators.Job{
+					{
+						Name:       "build",
+						Source:     validators.JobSourceCheckRun,
+						State:      validators.JobStateSuccess,
+						Conclusion: checkRunSuccessConclusion,
+					},
+				},
+			},
+		},
+		"returns error when a required job is failing even if it is ignored": {
+			selfJobName:  "self-job",
+			ignoredJobs:  mustJobPatterns("job-*"),
+			requiredJobs: mustJobPatterns("job-02"),
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(failureState),
+							},
+							{
+								Context: stringPtr("job-02"),
+								State:   stringPtr(failureState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				totalJobs:   []string{"job-02"},
+				errJobs:     []string{"job-02"},
+				ignoredJobs: []string{"job-01"},
+				ignoredBy:   map[string]string{"job-01": "job-*"},
+			}).Detail(),
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-02"},
+				completeJobs: []string{},
+				errJobs:      []string{"job-02"},
+				ignoredJobs:  []string{"job-01"},
+				ignoredBy:    map[string]string{"job-01": "job-*"},
+				jobs: []*validators.Job{
+					{
+						Name:         "job-01",
+						Source:       validators.JobSourceStatus,
+						State:        validators.JobStateIgnored,
+						Conclusion:   failureState,
+						IgnoreReason: "matched ignored job pattern job-*",
+					},
+					{
+						Name:       "job-02",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateFailure,
+						Conclusion: failureState,
+					},
+				},
+			},
+		},
+		"returns failed status and nil when only this job itself is reported with minimum jobs": {
+			selfJobName: "self-job",
+			minJobs:     1,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:   stringPtr("self-job"),
+								Status: stringPtr("in_progress"),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{},
+				completeJobs: []string{},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				waitingFor:   "0 out of 1 jobs reported",
+			},
+		},
+		"returns succeeded status and nil when a failed attempt is superseded by a successful re-run": {
+			selfJobName: "self-job",
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("failure"),
+								StartedAt:  timestampPtr(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)),
+							},
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								StartedAt:  timestampPtr(time.Date(2021, 10, 1, 1, 0, 0, 0, time.UTC)),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:      true,
+				totalJobs:      []string{"job-01"},
+				completeJobs:   []string{"job-01"},
+				errJobs:        []string{},
+				ignoredJobs:    []string{},
+				ignoredBy:      map[string]string{},
+				supersededJobs: []string{"job-01 (error)"},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceCheckRun,
+						State:      validators.JobStateSuperseded,
+						Conclusion: "failure",
+						StartedAt:  timePtr(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)),
+					},
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceCheckRun,
+						State:      validators.JobStateSuccess,
+						Conclusion: checkRunSuccessConclusion,
+						StartedAt:  timePtr(time.Date(2021, 10, 1, 1, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:      tt.selfJobName,
+				selfCheckRunName: tt.selfCheckRunName,
+				ignoredJobs:      tt.ignoredJobs,
+				requiredJobs:     tt.requiredJobs,
+				minJobs:          tt.minJobs,
+				client:           tt.client,
+			}
+			got, err := sv.Validate(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.wantErrStr {
+					t.Errorf("statusValidator.Validate() error.Error() = %s, wantErrStr %s", err.Error(), tt.wantErrStr)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("statusValidator.Validate() status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_waitingFor(t *testing.T) {
+	tests := map[string]struct {
+		minJobs    int
+		settleTime time.Duration
+		elapsed    time.Duration
+		reported   int
+		want       string
+	}{
+		"returns empty when neither minimum jobs nor settle time is set": {
+			reported: 0,
+			want:     "",
+		},
+		"returns reason when reported jobs are less than minimum jobs": {
+			minJobs:  2,
+			reported: 1,
+			want:     "1 out of 2 jobs reported",
+		},
+		"returns empty when reported jobs reach minimum jobs": {
+			minJobs:  2,
+			reported: 2,
+			want:     "",
+		},
+		"returns reason when settle time has not elapsed": {
+			settleTime: time.Minute,
+			elapsed:    20 * time.Second,
+			reported:   3,
+			want:       "settle time 40s remaining",
+		},
+		"returns empty when settle time has elapsed": {
+			settleTime: time.Minute,
+			elapsed:    2 * time.Minute,
+			want:       "",
+		},
+		"returns empty when settle time has elapsed even if reported jobs are less than minimum jobs": {
+			minJobs:    2,
+			settleTime: time.Minute,
+			elapsed:    2 * time.Minute,
+			reported:   1,
+			want:       "",
+		},
+		"returns empty when reported jobs reach minimum jobs before settle time elapses": {
+			minJobs:    2,
+			settleTime: time.Minute,
+			elapsed:    20 * time.Second,
+			reported:   2,
+			want:       "",
+		},
+		"returns reason when neither reported jobs nor settle time is enough": {
+			minJobs:    2,
+			settleTime: time.Minute,
+			elapsed:    20 * time.Second,
+			reported:   1,
+			want:       "1 out of 2 jobs reported, settle time 40s remaining",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				minJobs:    tt.minJobs,
+				settleTime: tt.settleTime,
+				startedAt:  time.Now().Add(-tt.elapsed),
+			}
+			if got := sv.waitingFor(tt.reported); got != tt.want {
+				t.Errorf("statusValidator.waitingFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_failureWindow(t *testing.T) {
+	client := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{
+					{
+						Context: stringPtr("job-01"),
+						State:   stringPtr(successState),
+					},
+					{
+						Context: stringPtr("job-02"),
+						State:   stringPtr(failureState),
+					},
+				},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{}, nil, nil
+		},
+	}
+
+	tests := map[string]struct {
+		failureWindow time.Duration
+		failedSince   map[string]time.Time
+		wantErr       bool
+		wantStatus    validators.Status
+	}{
+		"returns error when failure window is not set": {
+			wantErr: true,
+		},
+		"returns failed status and nil when the failure is within the window": {
+			failureWindow: 5 * time.Minute,
+			wantErr:       false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"job-02"},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				retryingJobs: []string{"job-02: 5m0s remaining"},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:       "job-02",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateFailure,
+						Conclusion: failureState,
+					},
+				},
+			},
+		},
+		"returns error when the failure has outlived the window": {
+			failureWindow: 5 * time.Minute,
+			failedSince:   map[string]time.Time{"job-02": time.Now().Add(-10 * time.Minute)},
+			wantErr:       true,
+		},
+		"returns failed status and nil when another job has outlived the window but is no longer failing": {
+			failureWindow: 5 * time.Minute,
+			failedSince:   map[string]time.Time{"job-01": time.Now().Add(-10 * time.Minute)},
+			wantErr:       false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"job-02"},
+				ignoredJobs:  []string{},
+				ignoredBy:    map[string]string{},
+				retryingJobs: []string{"job-02: 5m0s remaining"},
+				jobs: []*validators.Job{
+					{
+						Name:       "job-01",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateSuccess,
+						Conclusion: successState,
+					},
+					{
+						Name:       "job-02",
+						Source:     validators.JobSourceStatus,
+						State:      validators.JobStateFailure,
+						Conclusion: failureState,
+					},
+				},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:   "self-job",
+				failureWindow: tt.failureWindow,
+				failedSince:   tt.failedSince,
+				client:        client,
+			}
+			got, err := sv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("statusValidator.Validate() status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_ghaStatus_toJob(t *testing.T) {
+	startedAt := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(time.Minute)
+
+	tests := map[string]struct {
+		s     *ghaStatus
+		state validators.JobState
+		want  *validators.Job
+	}{
+		"returns job with timestamps of the completed check run": {
+			s: &ghaStatus{
+				Job:        "job-01",
+				State:      successState,
+				Source:     validators.JobSourceCheckRun,
+				Conclusion: checkRunSuccessConclusion,
+				URL:        "https://github.com/upsidr/merge-gatekeeper/runs/1",
+				StartedAt:  startedAt,
+				UpdatedAt:  completedAt,
+			},
+			state: validators.JobStateSuccess,
+			want: &validators.Job{
+				Name:        "job-01",
+				Source:      validators.JobSourceCheckRun,
+				State:       validators.JobStateSuccess,
+				Conclusion:  checkRunSuccessConclusion,
+				URL:         "https://github.com/upsidr/merge-gatekeeper/runs/1",
+				StartedAt:   &startedAt,
+				CompletedAt: &completedAt,
+			},
+		},
+		"returns job without completion time when the status is pending": {
+			s: &ghaStatus{
+				Job:        "job-02",
+				State:      pendingState,
+				Source:     validators.JobSourceStatus,
+				Conclusion: pendingState,
+				URL:        "https://ci.example.com/builds/2",
+				UpdatedAt:  completedAt,
+			},
+			state: validators.JobStatePending,
+			want: &validators.Job{
+				Name:       "job-02",
+				Source:     validators.JobSourceStatus,
+				State:      validators.JobStatePending,
+				Conclusion: pendingState,
+				URL:        "https://ci.example.com/builds/2",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.s.toJob(tt.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ghaStatus.toJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ghaStatus_newerThan(t *testing.T) {
+	startedAt := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		s, other *ghaStatus
+		want     bool
+	}{
+		"prefers later start": {
+			s:     &ghaStatus{State: successState, Source: validators.JobSourceCheckRun, StartedAt: startedAt.Add(time.Minute)},
+			other: &ghaStatus{State: errorState, Source: validators.JobSourceCheckRun, StartedAt: startedAt},
+			want:  true,
+		},
+		"prefers later update when the starts are equal": {
+			s:     &ghaStatus{State: successState, Source: validators.JobSourceStatus, UpdatedAt: startedAt.Add(time.Minute)},
+			other: &ghaStatus{State: errorState, Source: validators.JobSourceCheckRun, UpdatedAt: startedAt},
+			want:  true,
+		},
+		"prefers larger ID in the same source when times are equal": {
+			s:     &ghaStatus{State: successState, Source: validators.JobSourceCheckRun, ID: 2, StartedAt: startedAt},
+			other: &ghaStatus{State: errorState, Source: validators.JobSourceCheckRun, ID: 1, StartedAt: startedAt},
+			want:  true,
+		},
+		"prefers pending attempt across sources when times are zero": {
+			s:     &ghaStatus{State: pendingState, Source: validators.JobSourceCheckRun, ID: 1},
+			other: &ghaStatus{State: errorState, Source: validators.JobSourceStatus, ID: 2},
+			want:  true,
+		},
+		"prefers failure over success across sources when times are equal": {
+			s:     &ghaStatus{State: errorState, Source: validators.JobSourceStatus, ID: 1, UpdatedAt: startedAt},
+			other: &ghaStatus{State: successState, Source: validators.JobSourceCheckRun, ID: 2, UpdatedAt: startedAt},
+			want:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// The result must not depend on which attempt is listed first.
+			if got := tt.s.newerThan(tt.other); got != tt.want {
+				t.Errorf("ghaStatus.newerThan() = %v, want %v", got, tt.want)
+			}
+			if got := tt.other.newerThan(tt.s); got == tt.want {
+				t.Errorf("ghaStatus.newerThan() of the reversed order = %v, want %v", got, !tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_listStatuses(t *testing.T) {
+	type fields struct {
+		repo               string
+		owner              string
+		ref                string
+		selfJobName        string
+		conclusionPolicies map[string]conclusionPolicy
+		includedApps       []string
+		excludedCreators   []string
+		client             github.Client
+	}
+	type test struct {
+		fields  fields
+		ctx     context.Context
+		wantErr bool
+		want    []*ghaStatus
+	}
+	tests := map[string]test{
+		"prefers failure when a commit status and a check run report the same job without times": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("failure"),
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:        "job-01",
+						State:      errorState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: "failure",
+						Superseded: []*ghaStatus{
+							{
+								Job:        "job-01",
+								State:      successState,
+								Source:     validators.JobSourceStatus,
+								Conclusion: successState,
+							},
+						},
+					},
+				},
+			}
+		}(),
+		"succeeds to get job statuses even if the same job exists": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							// The first element here is the latest state.
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("job-01"), // Same as above job name, and thus should be disregarded as old job status.
+								State:   stringPtr(errorState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							// The first element here is the latest state.
+							{
+								Name:   stringPtr("job-02"),
+								Status: stringPtr("failure"),
+							},
+							{
+								Name:       stringPtr("job-02"), // Same as above job name, and thus should be disregarded as old job status.
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunNeutralConclusion),
+							},
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunNeutralConclusion),
+							},
+							{
+								Name:       stringPtr("job-04"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+							},
+							{
+								Name:       stringPtr("job-05"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("failure"),
+							},
+							{
+								Name:       stringPtr("job-06"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSkipConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:        "job-01",
+						State:      successState,
+						Source:     validators.JobSourceStatus,
+						Conclusion: successState,
+						Superseded: []*ghaStatus{
+							{
+								Job:        "job-01",
+								State:      errorState,
+								Source:     validators.JobSourceStatus,
+								Conclusion: errorState,
+							},
+						},
+					},
+					{
+						Job:    "job-02",
+						State:  pendingState,
+						Source: validators.JobSourceCheckRun,
+						Superseded: []*ghaStatus{
+							{
+								Job:        "job-02",
+								State:      successState,
+								Source:     validators.JobSourceCheckRun,
+								Conclusion: checkRunNeutralConclusion,
+							},
+						},
+					},
+					{
+						Job:        "job-03",
+						State:      successState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunNeutralConclusion,
+					},
+					{
+						Job:        "job-04",
+						State:      successState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunSuccessConclusion,
+					},
+					{
+						Job:        "job-05",
+						State:      errorState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: "failure",
+					},
+					{
+						Job:        "job-06",
+						State:      ignoredState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunSkipConclusion,
+					},
+				},
+			}
+		}(),
+		"succeeds to get job statuses with conclusion policies": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("cancelled"),
+							},
+							{
+								Name:       stringPtr("job-02"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunNeutralConclusion),
+							},
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("stale"),
+							},
+							{
+								Name:       stringPtr("job-04"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSkipConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+					conclusionPolicies: map[string]conclusionPolicy{
+						"cancelled":               pendingPolicy,
+						checkRunNeutralConclusion: failurePolicy,
+						"stale":                   ignorePolicy,
+						checkRunSkipConclusion:    successPolicy,
+					},
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:        "job-01",
+						State:      pendingState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: "cancelled",
+					},
+					{
+						Job:        "job-02",
+						State:      errorState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunNeutralConclusion,
+					},
+					{
+						Job:        "job-03",
+						State:      ignoredState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: "stale",
+					},
+					{
+						Job:        "job-04",
+						State:      successState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunSkipConclusion,
+					},
+				},
+			}
+		}(),
+		"succeeds to get the most recent attempts of the same jobs": func() test {
+			base := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context:   stringPtr("job-01"),
+								State:     stringPtr(errorState),
+								UpdatedAt: timePtr(base),
+							},
+							{
+								Context:   stringPtr("job-01"), // Updated later, and thus should supersede the above.
+								State:     stringPtr(successState),
+								UpdatedAt: timePtr(base.Add(time.Minute)),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:        stringPtr("job-02"),
+								Status:      stringPtr(checkRunCompletedStatus),
+								Conclusion:  stringPtr("failure"),
+								StartedAt:   timestampPtr(base),
+								CompletedAt: timestampPtr(base.Add(time.Minute)),
+							},
+							{
+								Name:      stringPtr("job-02"), // Re-run started later, and thus should supersede the above.
+								Status:    stringPtr("in_progress"),
+								StartedAt: timestampPtr(base.Add(2 * time.Minute)),
+							},
+							{
+								Name:        stringPtr("job-03"),
+								Status:      stringPtr(checkRunCompletedStatus),
+								Conclusion:  stringPtr(checkRunSkipConclusion),
+								StartedAt:   timestampPtr(base.Add(time.Minute)),
+								CompletedAt: timestampPtr(base.Add(time.Minute)),
+							},
+							{
+								Name:        stringPtr("job-03"), // Older than the skipped one, and thus should be superseded.
+								Status:      stringPtr(checkRunCompletedStatus),
+								Conclusion:  stringPtr("failure"),
+								StartedAt:   timestampPtr(base),
+								CompletedAt: timestampPtr(base),
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:        "job-01",
+						State:      successState,
+						Source:     validators.JobSourceStatus,
+						Conclusion: successState,
+						UpdatedAt:  base.Add(time.Minute),
+						Superseded: []*ghaStatus{
+							{
+								Job:        "job-01",
+								State:      errorState,
+								Source:     validators.JobSourceStatus,
+								Conclusion: errorState,
+								UpdatedAt:  base,
+							},
+						},
+					},
+					{
+						Job:       "job-02",
+						State:     pendingState,
+						Source:    validators.JobSourceCheckRun,
+						StartedAt: base.Add(2 * time.Minute),
+						Superseded: []*ghaStatus{
+							{
+								Job:        "job-02",
+								State:      errorState,
+								Source:     validators.JobSourceCheckRun,
+								Conclusion: "failure",
+								StartedAt:  base,
+								UpdatedAt:  base.Add(time.Minute),
+							},
+						},
+					},
+					{
+						Job:        "job-03",
+						State:      ignoredState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunSkipConclusion,
+						StartedAt:  base.Add(time.Minute),
+						UpdatedAt:  base.Add(time.Minute),
+						Superseded: []*ghaStatus{
+							{
+								Job:        "job-03",
+								State:      errorState,
+								Source:     validators.JobSourceCheckRun,
+								Conclusion: "failure",
+								StartedAt:  base,
+								UpdatedAt:  base,
+							},
+						},
+					},
+				},
+			}
+		}(),
+		"succeeds to get job statuses filtered by app and creator": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+								Creator: &github.User{Login: stringPtr("external-ci")},
+							},
+							{
+								Context: stringPtr("job-02"),
+								State:   stringPtr(errorState),
+								Creator: &github.User{Login: stringPtr("someone")},
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								App:        &github.App{Slug: stringPtr("github-actions")},
+							},
+							{
+								Name:       stringPtr("codecov/patch"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("failure"),
+								App:        &github.App{Slug: stringPtr("codecov")},
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:           c,
+					selfJobName:      "self-job",
+					owner:            "test-owner",
+					repo:             "test-repo",
+					ref:              "main",
+					includedApps:     []string{"github-actions"},
+					excludedCreators: []string{"someone"},
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:        "job-01",
+						State:      successState,
+						Source:     validators.JobSourceStatus,
+						Conclusion: successState,
+					},
+					{
+						Job:        "job-03",
+						State:      successState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunSuccessConclusion,
+					},
+				},
+			}
+		}(),
 		"returns error when the GetCombinedStatus returns an error": func() test {
 			c := &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
@@ -729,24 +2063,39 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 				wantErr: false,
 				want: []*ghaStatus{
 					{
-						Job:   "job-01",
-						State: successState,
+						Job:        "job-01",
+						State:      successState,
+						Source:     validators.JobSourceStatus,
+						Conclusion: successState,
+					},
+					{
+						Job:    "job-02",
+						State:  pendingState,
+						Source: validators.JobSourceCheckRun,
 					},
 					{
-						Job:   "job-02",
-						State: pendingState,
+						Job:        "job-03",
+						State:      successState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunNeutralConclusion,
 					},
 					{
-						Job:   "job-03",
-						State: successState,
+						Job:        "job-04",
+						State:      successState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunSuccessConclusion,
 					},
 					{
-						Job:   "job-04",
-						State: successState,
+						Job:        "job-05",
+						State:      errorState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: "failure",
 					},
 					{
-						Job:   "job-05",
-						State: errorState,
+						Job:        "job-06",
+						State:      ignoredState,
+						Source:     validators.JobSourceCheckRun,
+						Conclusion: checkRunSkipConclusion,
 					},
 				},
 			}
@@ -769,28 +2118,25 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 				}
 
 				expectedGhaStatuses[i] = &ghaStatus{
-					Job:   fmt.Sprintf("job-%d", i),
-					State: successState,
+					Job:        fmt.Sprintf("job-%d", i),
+					State:      successState,
+					Source:     validators.JobSourceStatus,
+					Conclusion: successState,
+					// The check run with the same name as the status is regarded as another attempt of the job.
+					Superseded: []*ghaStatus{
+						{
+							Job:        fmt.Sprintf("job-%d", i),
+							State:      successState,
+							Source:     validators.JobSourceCheckRun,
+							Conclusion: checkRunNeutralConclusion,
+						},
+					},
 				}
 			}
 
 			c := &mock.Client{
-				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					max := min(opts.Page*opts.PerPage, len(statuses))
-					sts := statuses[(opts.Page-1)*opts.PerPage : max]
-					l := len(sts)
-					return &github.CombinedStatus{
-						Statuses:   sts,
-						TotalCount: &l,
-					}, nil, nil
-				},
-				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					l := len(checkRuns)
-					return &github.ListCheckRunsResults{
-						CheckRuns: checkRuns,
-						Total:     &l,
-					}, nil, nil
-				},
+				GetCombinedStatusFunc:   mock.CombinedStatusPages(statuses),
+				ListCheckRunsForRefFunc: mock.CheckRunPages(checkRuns),
 			}
 			return test{
 				fields: fields{
@@ -822,28 +2168,25 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 				}
 
 				expectedGhaStatuses[i] = &ghaStatus{
-					Job:   fmt.Sprintf("job-%d", i),
-					State: successState,
+					Job:        fmt.Sprintf("job-%d", i),
+					State:      successState,
+					Source:     validators.JobSourceStatus,
+					Conclusion: successState,
+					// The check run with the same name as the status is regarded as another attempt of the job.
+					Superseded: []*ghaStatus{
+						{
+							Job:        fmt.Sprintf("job-%d", i),
+							State:      successState,
+							Source:     validators.JobSourceCheckRun,
+							Conclusion: checkRunNeutralConclusion,
+						},
+					},
 				}
 			}
 
 			c := &mock.Client{
-				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					max := min(opts.Page*opts.PerPage, len(statuses))
-					sts := statuses[(opts.Page-1)*opts.PerPage : max]
-					l := len(sts)
-					return &github.CombinedStatus{
-						Statuses:   sts,
-						TotalCount: &l,
-					}, nil, nil
-				},
-				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					l := len(checkRuns)
-					return &github.ListCheckRunsResults{
-						CheckRuns: checkRuns,
-						Total:     &l,
-					}, nil, nil
-				},
+				GetCombinedStatusFunc:   mock.CombinedStatusPages(statuses),
+				ListCheckRunsForRefFunc: mock.CheckRunPages(checkRuns),
 			}
 			return test{
 				fields: fields{
@@ -875,28 +2218,25 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 				}
 
 				expectedGhaStatuses[i] = &ghaStatus{
-					Job:   fmt.Sprintf("job-%d", i),
-					State: successState,
+					Job:        fmt.Sprintf("job-%d", i),
+					State:      successState,
+					Source:     validators.JobSourceStatus,
+					Conclusion: successState,
+					// The check run with the same name as the status is regarded as another attempt of the job.
+					Superseded: []*ghaStatus{
+						{
+							Job:        fmt.Sprintf("job-%d", i),
+							State:      successState,
+							Source:     validators.JobSourceCheckRun,
+							Conclusion: checkRunNeutralConclusion,
+						},
+					},
 				}
 			}
 
 			c := &mock.Client{
-				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					max := min(opts.Page*opts.PerPage, len(statuses))
-					sts := statuses[(opts.Page-1)*opts.PerPage : max]
-					l := len(sts)
-					return &github.CombinedStatus{
-						Statuses:   sts,
-						TotalCount: &l,
-					}, nil, nil
-				},
-				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					l := len(checkRuns)
-					return &github.ListCheckRunsResults{
-						CheckRuns: checkRuns,
-						Total:     &l,
-					}, nil, nil
-				},
+				GetCombinedStatusFunc:   mock.CombinedStatusPages(statuses),
+				ListCheckRunsForRefFunc: mock.CheckRunPages(checkRuns),
 			}
 			return test{
 				fields: fields{
@@ -914,13 +2254,16 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			sv := &statusValidator{
-				repo:        tt.fields.repo,
-				owner:       tt.fields.owner,
-				ref:         tt.fields.ref,
-				selfJobName: tt.fields.selfJobName,
-				client:      tt.fields.client,
+				repo:               tt.fields.repo,
+				owner:              tt.fields.owner,
+				ref:                tt.fields.ref,
+				selfJobName:        tt.fields.selfJobName,
+				conclusionPolicies: tt.fields.conclusionPolicies,
+				includedApps:       tt.fields.includedApps,
+				excludedCreators:   tt.fields.excludedCreators,
+				client:             tt.fields.client,
 			}
-			got, err := sv.listGhaStatuses(tt.ctx)
+			got, _, err := sv.listGhaStatuses(tt.ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("statusValidator.listStatuses() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -935,3 +2278,164 @@ func Test_statusValidator_listStatuses(t *testing.T) {
 		})
 	}
 }
+
+func Test_statusValidator_getCombinedStatus(t *testing.T) {
+	statuses := make([]*github.RepoStatus, 250)
+	for i := range statuses {
+		statuses[i] = &github.RepoStatus{
+			Context: stringPtr(fmt.Sprintf("job-%d", i)),
+			State:   stringPtr(successState),
+		}
+	}
+
+	tests := map[string]struct {
+		client    github.Client
+		wantLen   int
+		wantErr   bool
+		wantErrIs error
+	}{
+		"succeeds to retrieve all statuses across pages": {
+			client: &mock.Client{
+				GetCombinedStatusFunc: mock.CombinedStatusPages(statuses),
+			},
+			wantLen: len(statuses),
+		},
+		"succeeds to retrieve statuses when the response is nil": {
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: statuses[:10]}, nil, nil
+				},
+			},
+			wantLen: 10,
+		},
+		"returns error when a page is empty but the next page exists": {
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, &github.Response{NextPage: opts.Page + 1}, nil
+				},
+			},
+			wantErr:   true,
+			wantErrIs: ErrInconsistentPagination,
+		},
+		"returns error when the pages never end": {
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: statuses[:1]}, &github.Response{NextPage: opts.Page + 1}, nil
+				},
+			},
+			wantErr:   true,
+			wantErrIs: github.ErrTooManyPages,
+		},
+		"returns error when the second page returns an error": {
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					if opts.Page > 1 {
+						return nil, nil, errors.New("err")
+					}
+					return mock.CombinedStatusPages(statuses)(ctx, owner, repo, ref, opts)
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				client: tt.client,
+			}
+			got, err := sv.getCombinedStatus(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.getCombinedStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("statusValidator.getCombinedStatus() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("statusValidator.getCombinedStatus() length = %d, want %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_listCheckRunsForRef(t *testing.T) {
+	checkRuns := make([]*github.CheckRun, 250)
+	for i := range checkRuns {
+		checkRuns[i] = &github.CheckRun{
+			Name:       stringPtr(fmt.Sprintf("job-%d", i)),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunSuccessConclusion),
+		}
+	}
+
+	tests := map[string]struct {
+		client       github.Client
+		wantLen      int
+		wantUnlisted int
+		wantErr      bool
+		wantErrIs    error
+	}{
+		"succeeds to retrieve all check runs across pages": {
+			client: &mock.Client{
+				ListCheckRunsForRefFunc: mock.CheckRunPages(checkRuns),
+			},
+			wantLen: len(checkRuns),
+		},
+		"succeeds to retrieve check runs when the response is nil": {
+			client: &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: checkRuns[:10]}, nil, nil
+				},
+			},
+			wantLen: 10,
+		},
+		"returns error when a page is empty but the next page exists": {
+			client: &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					total := len(checkRuns)
+					return &github.ListCheckRunsResults{Total: &total}, &github.Response{NextPage: opts.Page + 1}, nil
+				},
+			},
+			wantErr:   true,
+			wantErrIs: ErrInconsistentPagination,
+		},
+		"succeeds when the last page is reached before the total, as check runs change between pages": {
+			client: &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					total := len(checkRuns)
+					return &github.ListCheckRunsResults{CheckRuns: checkRuns[:10], Total: &total}, &github.Response{}, nil
+				},
+			},
+			wantLen:      10,
+			wantUnlisted: len(checkRuns) - 10,
+		},
+		"returns error when the pages never end": {
+			client: &mock.Client{
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: checkRuns[:1]}, &github.Response{NextPage: opts.Page + 1}, nil
+				},
+			},
+			wantErr:   true,
+			wantErrIs: github.ErrTooManyPages,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				client: tt.client,
+			}
+			got, unlisted, err := sv.listCheckRunsForRef(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.listCheckRunsForRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("statusValidator.listCheckRunsForRef() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("statusValidator.listCheckRunsForRef() length = %d, want %d", len(got), tt.wantLen)
+			}
+			if unlisted != tt.wantUnlisted {
+				t.Errorf("statusValidator.listCheckRunsForRef() unlisted = %d, want %d", unlisted, tt.wantUnlisted)
+			}
+		})
+	}
+}
@@ -2,14 +2,57 @@ package validators
 
 import (
 	"context"
+	"time"
 )
 
+// JobSource is the kind of GitHub API which reported the job.
+type JobSource string
+
+const (
+	JobSourceStatus   JobSource = "status"
+	JobSourceCheckRun JobSource = "check_run"
+)
+
+// JobState is the state of the job from the point of view of the validation.
+type JobState string
+
+const (
+	JobStateSuccess    JobState = "success"
+	JobStateFailure    JobState = "failure"
+	JobStatePending    JobState = "pending"
+	JobStateIgnored    JobState = "ignored"
+	JobStateMissing    JobState = "missing"    // The job is required, but has never been reported.
+	JobStateSuperseded JobState = "superseded" // The job is an older attempt, and not considered for the validation.
+)
+
+// Job is the structured result of a job checked by the validator.
+type Job struct {
+	Name   string    `json:"name"`
+	Source JobSource `json:"source,omitempty"`
+	State  JobState  `json:"state"`
+
+	// Conclusion is the raw state for commit statuses, or the raw conclusion for check runs.
+	Conclusion string `json:"conclusion,omitempty"`
+
+	URL          string     `json:"url,omitempty"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	IgnoreReason string     `json:"ignore_reason,omitempty"`
+}
+
 type Status interface {
 	Detail() string
 	IsSuccess() bool
+
+	// Jobs returns the structured results of the jobs checked by the validator.
+	// Validators which do not check any job return nil.
+	Jobs() []*Job
 }
 
 type Validator interface {
 	Name() string
+
+	// Validate returns the status of the validation.
+	// When the validation fails with an error, the status may also be returned so that the result can be reported.
 	Validate(ctx context.Context) (Status, error)
 }